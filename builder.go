@@ -8,6 +8,12 @@ type QueryBuilder interface {
 	Build(query rel.Query) (string, []any)
 }
 
+// AggregateBuilder is implemented by query builder that builds aggregate query itself,
+// so that the query parts defined by the builder are handled when only the aggregate is selected.
+type AggregateBuilder interface {
+	BuildAggregate(query rel.Query, mode string, field string) (string, []any)
+}
+
 type InsertBuilder interface {
 	Build(table string, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (string, []any)
 }
@@ -31,3 +37,24 @@ type TableBuilder interface {
 type IndexBuilder interface {
 	Build(index rel.Index) string
 }
+
+// InsertReturningBuilder is implemented by insert builder that can return columns of the inserted row.
+// It returns false when the dialect doesn't support returning, and the statement doesn't return any row.
+type InsertReturningBuilder interface {
+	BuildReturning(table string, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict, returning []string) (string, []any, bool)
+}
+
+// InsertAllReturningBuilder is implemented by insert all builder that can return columns of the inserted rows.
+type InsertAllReturningBuilder interface {
+	BuildReturning(table string, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict, returning []string) (string, []any, bool)
+}
+
+// UpdateReturningBuilder is implemented by update builder that can return columns of the updated rows.
+type UpdateReturningBuilder interface {
+	BuildReturning(table string, primaryField string, mutates map[string]rel.Mutate, filter rel.FilterQuery, returning []string) (string, []any, bool)
+}
+
+// DeleteReturningBuilder is implemented by delete builder that can return columns of the deleted rows.
+type DeleteReturningBuilder interface {
+	BuildReturning(table string, filter rel.FilterQuery, returning []string) (string, []any, bool)
+}
@@ -0,0 +1,320 @@
+package builder
+
+import (
+	"sort"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/go-rel/rel"
+)
+
+// ArrayOp defines how array field is compared.
+type ArrayOp int
+
+const (
+	// ArrayContainsOp filters array that contains all values: @>.
+	ArrayContainsOp ArrayOp = iota
+	// ArrayContainedByOp filters array which elements are all in values: <@.
+	ArrayContainedByOp
+	// ArrayOverlapOp filters array that has any element in common with values: &&.
+	ArrayOverlapOp
+	// ArrayAnyOp filters array that has an element equal to value: = ANY.
+	ArrayAnyOp
+)
+
+// ArrayFilter compares array field with values.
+//
+// When Filter.ArrayAsJSON is set, array is expected to be stored as JSON array and compared using JSON dialect.
+type ArrayFilter struct {
+	Field  string
+	Op     ArrayOp
+	Values []any
+}
+
+// ArrayContains filters rows which array field contains all values.
+func ArrayContains(field string, values ...any) rel.FilterQuery {
+	return arrayFilterQuery(ArrayFilter{Field: field, Op: ArrayContainsOp, Values: values})
+}
+
+// ArrayContainedBy filters rows which array field elements are all in values.
+func ArrayContainedBy(field string, values ...any) rel.FilterQuery {
+	return arrayFilterQuery(ArrayFilter{Field: field, Op: ArrayContainedByOp, Values: values})
+}
+
+// ArrayOverlap filters rows which array field has any element in common with values.
+func ArrayOverlap(field string, values ...any) rel.FilterQuery {
+	return arrayFilterQuery(ArrayFilter{Field: field, Op: ArrayOverlapOp, Values: values})
+}
+
+// ArrayAny filters rows which array field has an element equal to value.
+func ArrayAny(field string, value any) rel.FilterQuery {
+	return arrayFilterQuery(ArrayFilter{Field: field, Op: ArrayAnyOp, Values: []any{value}})
+}
+
+func arrayFilterQuery(filter ArrayFilter) rel.FilterQuery {
+	return rel.FilterQuery{
+		Type:  rel.FilterFragmentOp,
+		Field: filter.Field,
+		Value: []any{filter},
+	}
+}
+
+// arrayMutationSeq numbers array mutations in the order they're created.
+var arrayMutationSeq uint64
+
+// ArrayMutation appends values to, or removes value from array field.
+//
+// Multiple mutations of the same field are combined into a single assignment, applied in the order they're created.
+type ArrayMutation struct {
+	Field  string
+	Values []any
+	Remove bool
+	seq    uint64
+}
+
+// mutate returns fragment mutation that carries array mutation.
+// The mutation is keyed by its sequence, so that it doesn't replace other mutation of the same field.
+func (am ArrayMutation) mutate() rel.Mutate {
+	am.seq = atomic.AddUint64(&arrayMutationSeq, 1)
+
+	op := " APPEND #"
+	if am.Remove {
+		op = " REMOVE #"
+	}
+
+	return rel.Mutate{
+		Type:  rel.ChangeFragmentOp,
+		Field: am.Field + op + strconv.FormatUint(am.seq, 10),
+		Value: []any{am},
+	}
+}
+
+// ArrayAppend appends values to the end of array field.
+func ArrayAppend(field string, values ...any) rel.Mutate {
+	return ArrayMutation{Field: field, Values: values}.mutate()
+}
+
+// ArrayRemove removes all elements equal to value from array field.
+// Removal from JSON array is only supported for string element on PostgreSQL, and not supported on MySQL.
+func ArrayRemove(field string, value any) rel.Mutate {
+	return ArrayMutation{Field: field, Values: []any{value}, Remove: true}.mutate()
+}
+
+// arrayMutation returns array mutation carried by fragment mutation.
+func arrayMutation(mut rel.Mutate) (ArrayMutation, bool) {
+	if values, ok := mut.Value.([]any); ok && len(values) == 1 {
+		am, ok := values[0].(ArrayMutation)
+		return am, ok
+	}
+
+	return ArrayMutation{}, false
+}
+
+// WriteArray writes array filter to buffer.
+func (f Filter) WriteArray(buffer *Buffer, table string, filter ArrayFilter) {
+	if !f.ArrayAsJSON {
+		if filter.Op == ArrayAnyOp {
+			buffer.WriteValue(filter.Values[0])
+			buffer.WriteString("=ANY(")
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteByte(')')
+			return
+		}
+
+		buffer.WriteField(table, filter.Field)
+		switch filter.Op {
+		case ArrayContainsOp:
+			buffer.WriteString("@>")
+		case ArrayContainedByOp:
+			buffer.WriteString("<@")
+		case ArrayOverlapOp:
+			buffer.WriteString("&&")
+		}
+		buffer.WriteValue(f.arrayValue(filter.Values))
+		return
+	}
+
+	switch f.JSON {
+	case JSONMySQL:
+		switch filter.Op {
+		case ArrayContainsOp:
+			buffer.WriteString("JSON_CONTAINS(")
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteString(", ")
+			buffer.WriteValue(encodeJSON(filter.Values))
+			buffer.WriteByte(')')
+		case ArrayContainedByOp:
+			buffer.WriteString("JSON_CONTAINS(")
+			buffer.WriteValue(encodeJSON(filter.Values))
+			buffer.WriteString(", ")
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteByte(')')
+		case ArrayOverlapOp:
+			buffer.WriteString("JSON_OVERLAPS(")
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteString(", ")
+			buffer.WriteValue(encodeJSON(filter.Values))
+			buffer.WriteByte(')')
+		case ArrayAnyOp:
+			buffer.WriteValue(filter.Values[0])
+			buffer.WriteString(" MEMBER OF(")
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteByte(')')
+		}
+	case JSONSQLite:
+		switch filter.Op {
+		case ArrayContainsOp:
+			buffer.WriteString("NOT EXISTS (SELECT 1 FROM json_each(")
+			buffer.WriteValue(encodeJSON(filter.Values))
+			buffer.WriteString(") WHERE value NOT IN (SELECT value FROM json_each(")
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteString(")))")
+		case ArrayContainedByOp:
+			buffer.WriteString("NOT EXISTS (SELECT 1 FROM json_each(")
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteString(") WHERE value NOT IN (SELECT value FROM json_each(")
+			buffer.WriteValue(encodeJSON(filter.Values))
+			buffer.WriteString(")))")
+		case ArrayOverlapOp:
+			buffer.WriteString("EXISTS (SELECT 1 FROM json_each(")
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteString(") WHERE value IN (SELECT value FROM json_each(")
+			buffer.WriteValue(encodeJSON(filter.Values))
+			buffer.WriteString(")))")
+		case ArrayAnyOp:
+			buffer.WriteValue(filter.Values[0])
+			buffer.WriteString(" IN (SELECT value FROM json_each(")
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteString("))")
+		}
+	default:
+		// jsonb containment works for JSON array as well, and a scalar is contained by array that has it.
+		switch filter.Op {
+		case ArrayContainsOp, ArrayAnyOp:
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteString("@>CAST(")
+		case ArrayContainedByOp:
+			buffer.WriteField(table, filter.Field)
+			buffer.WriteString("<@CAST(")
+		case ArrayOverlapOp:
+			fail("%w: JSON array overlap", ErrUnsupported)
+		}
+		buffer.WriteValue(encodeJSON(filter.Values))
+		buffer.WriteString(" AS jsonb)")
+	}
+}
+
+// WriteArrayMutations writes assignment of field that combines all array mutations to buffer.
+func (f Filter) WriteArrayMutations(buffer *Buffer, field string, mutations []ArrayMutation) {
+	buffer.WriteEscape(field)
+	buffer.WriteByte('=')
+	f.writeArrayMutations(buffer, field, mutations)
+}
+
+func (f Filter) writeArrayMutations(buffer *Buffer, field string, mutations []ArrayMutation) {
+	if len(mutations) == 0 {
+		buffer.WriteEscape(field)
+		return
+	}
+
+	var (
+		last  = mutations[len(mutations)-1]
+		inner = mutations[:len(mutations)-1]
+	)
+
+	if !f.ArrayAsJSON {
+		if last.Remove {
+			buffer.WriteString("array_remove(")
+			f.writeArrayMutations(buffer, field, inner)
+			buffer.WriteString(", ")
+			buffer.WriteValue(last.Values[0])
+		} else {
+			buffer.WriteString("array_cat(")
+			f.writeArrayMutations(buffer, field, inner)
+			buffer.WriteString(", ")
+			buffer.WriteValue(f.arrayValue(last.Values))
+		}
+		buffer.WriteByte(')')
+		return
+	}
+
+	switch f.JSON {
+	case JSONMySQL:
+		if last.Remove {
+			fail("%w: JSON array element removal", ErrUnsupported)
+		}
+
+		buffer.WriteString("JSON_MERGE_PRESERVE(COALESCE(")
+		f.writeArrayMutations(buffer, field, inner)
+		buffer.WriteString(", JSON_ARRAY()), CAST(")
+		buffer.WriteValue(encodeJSON(last.Values))
+		buffer.WriteString(" AS JSON))")
+	case JSONSQLite:
+		if last.Remove {
+			buffer.WriteString("(SELECT json_group_array(value) FROM json_each(")
+			f.writeArrayMutations(buffer, field, inner)
+			buffer.WriteString(") WHERE value<>")
+			buffer.WriteValue(last.Values[0])
+			buffer.WriteByte(')')
+			return
+		}
+
+		buffer.WriteString("json_insert(coalesce(")
+		f.writeArrayMutations(buffer, field, inner)
+		buffer.WriteString(", '[]')")
+		for _, value := range last.Values {
+			buffer.WriteString(", '$[#]', ")
+			buffer.WriteValue(value)
+		}
+		buffer.WriteByte(')')
+	default:
+		if last.Remove {
+			buffer.WriteByte('(')
+			f.writeArrayMutations(buffer, field, inner)
+			buffer.WriteString("-")
+			buffer.WriteValue(last.Values[0])
+			buffer.WriteByte(')')
+			return
+		}
+
+		buffer.WriteString("(COALESCE(")
+		f.writeArrayMutations(buffer, field, inner)
+		buffer.WriteString(", '[]')||CAST(")
+		buffer.WriteValue(encodeJSON(last.Values))
+		buffer.WriteString(" AS jsonb))")
+	}
+}
+
+// arrayValue converts values to be bound as native array, which requires ArrayValue since driver can't bind []any.
+func (f Filter) arrayValue(values []any) any {
+	if f.ArrayValue == nil {
+		fail("%w: native array without Filter.ArrayValue", ErrUnsupported)
+	}
+
+	return f.ArrayValue(values)
+}
+
+// groupArrayMutations returns array mutations of each field, ordered by creation.
+func groupArrayMutations(mutates map[string]rel.Mutate) map[string][]ArrayMutation {
+	var (
+		groups map[string][]ArrayMutation
+	)
+
+	for _, mut := range mutates {
+		if am, ok := arrayMutation(mut); ok {
+			if groups == nil {
+				groups = make(map[string][]ArrayMutation)
+			}
+
+			groups[am.Field] = append(groups[am.Field], am)
+		}
+	}
+
+	for _, mutations := range groups {
+		sort.Slice(mutations, func(i, j int) bool {
+			return mutations[i].seq < mutations[j].seq
+		})
+	}
+
+	return groups
+}
@@ -0,0 +1,239 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_Write_array(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", IDSuffixEscapeChar: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		mysql         = Filter{ArrayAsJSON: true, JSON: JSONMySQL}
+		sqlite        = Filter{ArrayAsJSON: true, JSON: JSONSQLite}
+		native        = Filter{ArrayValue: func(values []any) any { return values }}
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		filter Filter
+		query  rel.FilterQuery
+	}{
+		{
+			result: "\"posts\".\"tags\"@>?",
+			args:   []any{[]any{"go", "sql"}},
+			filter: native,
+			query:  ArrayContains("tags", "go", "sql"),
+		},
+		{
+			result: "\"posts\".\"tags\"<@?",
+			args:   []any{[]any{"go", "sql"}},
+			filter: native,
+			query:  ArrayContainedBy("tags", "go", "sql"),
+		},
+		{
+			result: "\"posts\".\"tags\"&&?",
+			args:   []any{"{go,sql}"},
+			filter: Filter{ArrayValue: func(values []any) any { return "{go,sql}" }},
+			query:  ArrayOverlap("tags", "go", "sql"),
+		},
+		{
+			result: "?=ANY(\"posts\".\"tags\")",
+			args:   []any{"go"},
+			filter: native,
+			query:  ArrayAny("tags", "go"),
+		},
+		{
+			result: "\"posts\".\"tags\"@>CAST(? AS jsonb)",
+			args:   []any{"[\"go\"]"},
+			filter: Filter{ArrayAsJSON: true},
+			query:  ArrayAny("tags", "go"),
+		},
+		{
+			result: "JSON_CONTAINS(\"posts\".\"tags\", ?)",
+			args:   []any{"[\"go\",\"sql\"]"},
+			filter: mysql,
+			query:  ArrayContains("tags", "go", "sql"),
+		},
+		{
+			result: "JSON_CONTAINS(?, \"posts\".\"tags\")",
+			args:   []any{"[\"go\",\"sql\"]"},
+			filter: mysql,
+			query:  ArrayContainedBy("tags", "go", "sql"),
+		},
+		{
+			result: "JSON_OVERLAPS(\"posts\".\"tags\", ?)",
+			args:   []any{"[\"go\",\"sql\"]"},
+			filter: mysql,
+			query:  ArrayOverlap("tags", "go", "sql"),
+		},
+		{
+			result: "? MEMBER OF(\"posts\".\"tags\")",
+			args:   []any{"go"},
+			filter: mysql,
+			query:  ArrayAny("tags", "go"),
+		},
+		{
+			result: "NOT EXISTS (SELECT 1 FROM json_each(?) WHERE value NOT IN (SELECT value FROM json_each(\"posts\".\"tags\")))",
+			args:   []any{"[\"go\",\"sql\"]"},
+			filter: sqlite,
+			query:  ArrayContains("tags", "go", "sql"),
+		},
+		{
+			result: "NOT EXISTS (SELECT 1 FROM json_each(\"posts\".\"tags\") WHERE value NOT IN (SELECT value FROM json_each(?)))",
+			args:   []any{"[\"go\",\"sql\"]"},
+			filter: sqlite,
+			query:  ArrayContainedBy("tags", "go", "sql"),
+		},
+		{
+			result: "EXISTS (SELECT 1 FROM json_each(\"posts\".\"tags\") WHERE value IN (SELECT value FROM json_each(?)))",
+			args:   []any{"[\"go\",\"sql\"]"},
+			filter: sqlite,
+			query:  ArrayOverlap("tags", "go", "sql"),
+		},
+		{
+			result: "? IN (SELECT value FROM json_each(\"posts\".\"tags\"))",
+			args:   []any{"go"},
+			filter: sqlite,
+			query:  ArrayAny("tags", "go"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			buffer := bufferFactory.Create()
+			test.filter.Write(&buffer, "posts", test.query, nil)
+			assert.Equal(t, test.result, buffer.String())
+			assert.Equal(t, test.args, buffer.Arguments())
+		})
+	}
+}
+
+func TestFilter_Write_arrayUnsupported(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}}
+		buffer        = bufferFactory.Create()
+	)
+
+	defer func() {
+		err, _ := recover().(error)
+		assert.True(t, errors.Is(err, ErrUnsupported))
+	}()
+
+	Filter{ArrayAsJSON: true}.Write(&buffer, "posts", ArrayOverlap("tags", "go"), nil)
+}
+
+func TestFilter_Write_arrayWithoutArrayValue(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}}
+		buffer        = bufferFactory.Create()
+	)
+
+	defer func() {
+		err, _ := recover().(error)
+		assert.True(t, errors.Is(err, ErrUnsupported))
+	}()
+
+	Filter{}.Write(&buffer, "posts", ArrayContains("tags", "go"), nil)
+}
+
+func TestUpdate_Build_array(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		mutates       = mutateMap(
+			rel.Set("id", 1),
+			ArrayAppend("tags", "go", "sql"),
+			ArrayRemove("tags", "php"),
+			rel.Set("title", "rel"),
+		)
+		native = Filter{ArrayValue: func(values []any) any { return values }}
+	)
+
+	tests := []struct {
+		result  string
+		args    []any
+		filter  Filter
+		mutates map[string]rel.Mutate
+	}{
+		{
+			result:  "UPDATE `posts` SET `tags`=array_remove(array_cat(`tags`, ?), ?),`title`=? WHERE `posts`.`id`=?;",
+			args:    []any{[]any{"go", "sql"}, "php", "rel", 1},
+			filter:  native,
+			mutates: mutates,
+		},
+		{
+			result:  "UPDATE `posts` SET `tags`=((COALESCE(`tags`, '[]')||CAST(? AS jsonb))-?),`title`=? WHERE `posts`.`id`=?;",
+			args:    []any{"[\"go\",\"sql\"]", "php", "rel", 1},
+			filter:  Filter{ArrayAsJSON: true},
+			mutates: mutates,
+		},
+		{
+			result:  "UPDATE `posts` SET `tags`=JSON_MERGE_PRESERVE(COALESCE(`tags`, JSON_ARRAY()), CAST(? AS JSON)) WHERE `posts`.`id`=?;",
+			args:    []any{"[\"go\",\"sql\"]", 1},
+			filter:  Filter{ArrayAsJSON: true, JSON: JSONMySQL},
+			mutates: mutateMap(ArrayAppend("tags", "go", "sql")),
+		},
+		{
+			result:  "UPDATE `posts` SET `tags`=(SELECT json_group_array(value) FROM json_each(json_insert(coalesce(`tags`, '[]'), '$[#]', ?, '$[#]', ?)) WHERE value<>?),`title`=? WHERE `posts`.`id`=?;",
+			args:    []any{"go", "sql", "php", "rel", 1},
+			filter:  Filter{ArrayAsJSON: true, JSON: JSONSQLite},
+			mutates: mutates,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			updateBuilder := Update{BufferFactory: bufferFactory, Query: Query{BufferFactory: bufferFactory, Filter: test.filter}, Filter: test.filter}
+			result, args := updateBuilder.Build("posts", "id", test.mutates, where.Eq("id", 1))
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
+func TestUpdate_Build_arrayUnsupported(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`"}}
+		filter        = Filter{ArrayAsJSON: true, JSON: JSONMySQL}
+		updateBuilder = Update{BufferFactory: bufferFactory, Filter: filter}
+	)
+
+	defer func() {
+		err, _ := recover().(error)
+		assert.True(t, errors.Is(err, ErrUnsupported))
+	}()
+
+	updateBuilder.Build("posts", "id", mutateMap(ArrayRemove("tags", "php")), where.Eq("id", 1))
+}
+
+func TestUpdate_Build_arrayOrder(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`"}}
+		filter        = Filter{ArrayValue: func(values []any) any { return values }}
+		updateBuilder = Update{BufferFactory: bufferFactory, Query: Query{BufferFactory: bufferFactory, Filter: filter}, Filter: filter}
+		mutates       = mutateMap(
+			ArrayRemove("tags", "php"),
+			ArrayAppend("tags", "go"),
+			ArrayAppend("tags", "sql"),
+		)
+	)
+
+	result, args := updateBuilder.Build("posts", "id", mutates, where.Eq("id", 1))
+	assert.Len(t, mutates, 3)
+	assert.Equal(t, "UPDATE `posts` SET `tags`=array_cat(array_cat(array_remove(`tags`, ?), ?), ?) WHERE `posts`.`id`=?;", result)
+	assert.Equal(t, []any{"php", []any{"go"}, []any{"sql"}, 1}, args)
+}
+
+// mutateMap keys mutates by field, the same way as rel.Apply.
+func mutateMap(mutates ...rel.Mutate) map[string]rel.Mutate {
+	result := make(map[string]rel.Mutate, len(mutates))
+	for _, mut := range mutates {
+		result[mut.Field] = mut
+	}
+
+	return result
+}
@@ -164,7 +164,12 @@ func (b Buffer) escape(table, value string) string {
 	} else if i := strings.Index(strings.ToLower(value), " as "); i > -1 {
 		escapedValue = b.escape(alias, value[:i]) + " AS " + b.Quoter.ID(value[i+4:])
 	} else if start, end := strings.IndexRune(value, '('), strings.IndexRune(value, ')'); start >= 0 && end >= 0 && end > start {
-		escapedValue = value[:start+1] + b.escape(alias, value[start+1:end]) + value[end:]
+		if end == start+1 {
+			// function without argument
+			escapedValue = value
+		} else {
+			escapedValue = value[:start+1] + b.escape(alias, value[start+1:end]) + value[end:]
+		}
 	} else {
 		parts := strings.Split(value, ".")
 		for i, part := range parts {
@@ -185,6 +190,34 @@ func (b Buffer) escape(table, value string) string {
 	return escapedValue.(string)
 }
 
+// WriteExpression writes expression as is, while each ? is replaced by placeholder of the next value.
+// ? inside single quoted string literal is written as is, as well as ? that has no remaining value.
+func (b *Buffer) WriteExpression(expr string, values ...any) {
+	var (
+		n      = 0
+		quoted = false
+		last   = 0
+	)
+
+	for i := 0; i < len(expr) && n < len(values); i++ {
+		switch expr[i] {
+		case '\'':
+			quoted = !quoted
+		case '?':
+			if quoted {
+				continue
+			}
+
+			b.WriteString(expr[last:i])
+			b.WriteValue(values[n])
+			last = i + 1
+			n++
+		}
+	}
+
+	b.WriteString(expr[last:])
+}
+
 // AddArguments appends multiple arguments without writing placeholder query..
 func (b *Buffer) AddArguments(args ...any) {
 	if b.arguments == nil {
@@ -188,3 +188,41 @@ func TestBuffer_InlineValue(t *testing.T) {
 		})
 	}
 }
+
+func TestBuffer_WriteExpression(t *testing.T) {
+	var (
+		bf = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+	)
+
+	tests := []struct {
+		expr   string
+		values []any
+		result string
+		args   []any
+	}{
+		{expr: "price * ?", values: []any{2}, result: "price * $1", args: []any{2}},
+		{expr: "COALESCE(nickname, ?) || ?", values: []any{"anon", "!"}, result: "COALESCE(nickname, $1) || $2", args: []any{"anon", "!"}},
+		{expr: "CASE WHEN note = '?' THEN ? END", values: []any{1}, result: "CASE WHEN note = '?' THEN $1 END", args: []any{1}},
+		{expr: "data ? 'key'", result: "data ? 'key'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			buffer := bf.Create()
+			buffer.WriteExpression(test.expr, test.values...)
+
+			assert.Equal(t, test.result, buffer.String())
+			assert.Equal(t, test.args, buffer.Arguments())
+		})
+	}
+
+	t.Run("inline values", func(t *testing.T) {
+		bf := bf
+		bf.InlineValues = true
+		buffer := bf.Create()
+		buffer.WriteExpression("COALESCE(nickname, ?)", "o'neil")
+
+		assert.Equal(t, "COALESCE(nickname, 'o''neil')", buffer.String())
+		assert.Nil(t, buffer.Arguments())
+	})
+}
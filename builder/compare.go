@@ -0,0 +1,114 @@
+package builder
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// BetweenFilter filters field value within low and high, inclusive.
+type BetweenFilter struct {
+	Field string
+	Low   any
+	High  any
+	Not   bool
+}
+
+func (bf BetweenFilter) filterQuery() rel.FilterQuery {
+	return rel.FilterQuery{
+		Type:  rel.FilterFragmentOp,
+		Field: bf.Field,
+		Value: []any{bf},
+	}
+}
+
+// Between filters field value within low and high, inclusive.
+func Between(field string, low, high any) rel.FilterQuery {
+	return BetweenFilter{Field: field, Low: low, High: high}.filterQuery()
+}
+
+// NotBetween filters field value outside low and high.
+func NotBetween(field string, low, high any) rel.FilterQuery {
+	return BetweenFilter{Field: field, Low: low, High: high, Not: true}.filterQuery()
+}
+
+// DistinctFilter compares field and value with NULL treated as a comparable value.
+type DistinctFilter struct {
+	Field string
+	Value any
+	Not   bool
+}
+
+func (df DistinctFilter) filterQuery() rel.FilterQuery {
+	return rel.FilterQuery{
+		Type:  rel.FilterFragmentOp,
+		Field: df.Field,
+		Value: []any{df},
+	}
+}
+
+// IsDistinctFrom filters field value that is not equal to value, NULL is distinct from non-NULL value.
+func IsDistinctFrom(field string, value any) rel.FilterQuery {
+	return DistinctFilter{Field: field, Value: value}.filterQuery()
+}
+
+// IsNotDistinctFrom filters field value that is equal to value, NULL is equal to NULL.
+func IsNotDistinctFrom(field string, value any) rel.FilterQuery {
+	return DistinctFilter{Field: field, Value: value, Not: true}.filterQuery()
+}
+
+// TupleFilter compares multiple fields as a row value.
+//
+// Op is one of rel.FilterEqOp, rel.FilterNeOp, rel.FilterLtOp, rel.FilterLteOp, rel.FilterGtOp, rel.FilterGteOp,
+// rel.FilterInOp and rel.FilterNinOp. Values contain a single row for comparison, and any number of rows for inclusion.
+type TupleFilter struct {
+	Fields []string
+	Op     rel.FilterOp
+	Values [][]any
+}
+
+func (tf TupleFilter) filterQuery() rel.FilterQuery {
+	return rel.FilterQuery{
+		Type:  rel.FilterFragmentOp,
+		Field: "TUPLE",
+		Value: []any{tf},
+	}
+}
+
+// TupleEq filters fields that are equal to values.
+func TupleEq(fields []string, values ...any) rel.FilterQuery {
+	return TupleFilter{Fields: fields, Op: rel.FilterEqOp, Values: [][]any{values}}.filterQuery()
+}
+
+// TupleNe filters fields that are not equal to values.
+func TupleNe(fields []string, values ...any) rel.FilterQuery {
+	return TupleFilter{Fields: fields, Op: rel.FilterNeOp, Values: [][]any{values}}.filterQuery()
+}
+
+// TupleLt filters fields that are less than values, compared in order.
+func TupleLt(fields []string, values ...any) rel.FilterQuery {
+	return TupleFilter{Fields: fields, Op: rel.FilterLtOp, Values: [][]any{values}}.filterQuery()
+}
+
+// TupleLte filters fields that are less than or equal to values, compared in order.
+func TupleLte(fields []string, values ...any) rel.FilterQuery {
+	return TupleFilter{Fields: fields, Op: rel.FilterLteOp, Values: [][]any{values}}.filterQuery()
+}
+
+// TupleGt filters fields that are greater than values, compared in order.
+func TupleGt(fields []string, values ...any) rel.FilterQuery {
+	return TupleFilter{Fields: fields, Op: rel.FilterGtOp, Values: [][]any{values}}.filterQuery()
+}
+
+// TupleGte filters fields that are greater than or equal to values, compared in order.
+func TupleGte(fields []string, values ...any) rel.FilterQuery {
+	return TupleFilter{Fields: fields, Op: rel.FilterGteOp, Values: [][]any{values}}.filterQuery()
+}
+
+// TupleIn filters fields that are equal to any of the rows.
+func TupleIn(fields []string, rows ...[]any) rel.FilterQuery {
+	return TupleFilter{Fields: fields, Op: rel.FilterInOp, Values: rows}.filterQuery()
+}
+
+// TupleNin filters fields that are not equal to any of the rows.
+func TupleNin(fields []string, rows ...[]any) rel.FilterQuery {
+	return TupleFilter{Fields: fields, Op: rel.FilterNinOp, Values: rows}.filterQuery()
+}
@@ -0,0 +1,51 @@
+package builder
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// Compound defines a set operation that combines the result of the query with another query.
+//
+// Sort, limit and offset of the query are applied to the combined result.
+type Compound struct {
+	Operator string
+	Query    rel.Query
+}
+
+func (c Compound) joinQuery() rel.JoinQuery {
+	return rel.JoinQuery{
+		Mode:      c.Operator,
+		Arguments: []any{c},
+	}
+}
+
+// hasCompound returns true when joins carry any set operation.
+func hasCompound(joins []rel.JoinQuery) bool {
+	for _, join := range joins {
+		if _, ok := queryClause(join).(Compound); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Union combines query result with the result of given query, duplicate rows are removed.
+func Union(query rel.Query) rel.JoinQuery {
+	return Compound{Operator: "UNION", Query: query}.joinQuery()
+}
+
+// UnionAll combines query result with the result of given query, duplicate rows are kept.
+func UnionAll(query rel.Query) rel.JoinQuery {
+	return Compound{Operator: "UNION ALL", Query: query}.joinQuery()
+}
+
+// Intersect keeps rows that are also returned by given query.
+func Intersect(query rel.Query) rel.JoinQuery {
+	return Compound{Operator: "INTERSECT", Query: query}.joinQuery()
+}
+
+// Except removes rows that are returned by given query.
+func Except(query rel.Query) rel.JoinQuery {
+	return Compound{Operator: "EXCEPT", Query: query}.joinQuery()
+}
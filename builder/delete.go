@@ -9,21 +9,31 @@ type Delete struct {
 	BufferFactory BufferFactory
 	Query         QueryWriter
 	Filter        Filter
+	Returning     Returning
 }
 
 // Build SQL query and its arguments.
 func (ds Delete) Build(table string, filter rel.FilterQuery) (string, []any) {
+	statement, args, _ := ds.BuildReturning(table, filter, nil)
+	return statement, args
+}
+
+// BuildReturning builds SQL query that returns columns of the deleted rows, and its arguments.
+// It returns false when returning is not supported, in which case the query doesn't return any row.
+func (ds Delete) BuildReturning(table string, filter rel.FilterQuery, returning []string) (string, []any, bool) {
 	buffer := ds.BufferFactory.Create()
 
 	buffer.WriteString("DELETE FROM ")
 	buffer.WriteTable(table)
+	ds.Returning.WriteOutput(&buffer, "DELETED", returning)
 
 	if !filter.None() {
 		buffer.WriteString(" WHERE ")
 		ds.Filter.Write(&buffer, table, filter, ds.Query)
 	}
 
+	ds.Returning.WriteClause(&buffer, returning)
 	buffer.WriteString(";")
 
-	return buffer.String(), buffer.Arguments()
+	return buffer.String(), buffer.Arguments(), ds.Returning.Supported()
 }
@@ -0,0 +1,38 @@
+package builder
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// Column references a column, it can be used as filter value to compare columns,
+// for example correlating sub query with the outer query.
+type Column string
+
+// ExistsFilter filters rows for which the sub query returns any row.
+type ExistsFilter struct {
+	Query rel.Query
+	Not   bool
+}
+
+func (ef ExistsFilter) filterQuery() rel.FilterQuery {
+	field := "EXISTS"
+	if ef.Not {
+		field = "NOT EXISTS"
+	}
+
+	return rel.FilterQuery{
+		Type:  rel.FilterFragmentOp,
+		Field: field,
+		Value: []any{ef},
+	}
+}
+
+// Exists filters rows for which the sub query returns any row.
+func Exists(query rel.Query) rel.FilterQuery {
+	return ExistsFilter{Query: query}.filterQuery()
+}
+
+// NotExists filters rows for which the sub query returns no row.
+func NotExists(query rel.Query) rel.FilterQuery {
+	return ExistsFilter{Query: query, Not: true}.filterQuery()
+}
@@ -1,11 +1,63 @@
 package builder
 
 import (
+	"math"
+	"reflect"
+
 	"github.com/go-rel/rel"
 )
 
+// InclusionStrategy defines how IN filter with many values is written.
+type InclusionStrategy int
+
+const (
+	// InclusionList writes a placeholder for each value: IN (?,?,?).
+	InclusionList InclusionStrategy = iota
+	// InclusionArray binds values as a single array: = ANY(?), which is the choice for PostgreSQL. It requires ArrayValue.
+	InclusionArray
+	// InclusionChunk splits values into multiple IN filters: (IN (?,?) OR IN (?)), for list size limit such as Oracle's.
+	// Each value still uses a placeholder.
+	InclusionChunk
+	// InclusionValues writes values inline as a VALUES list: IN (VALUES (1),(2)), which doesn't use any placeholder.
+	// Only numeric values are written inline, placeholder is used for each value when any of them isn't a finite number.
+	// It's supported by PostgreSQL, SQLite and SQL Server, but not by MySQL, which rejects VALUES list inside IN.
+	InclusionValues
+)
+
 // Filter builder.
-type Filter struct{}
+type Filter struct {
+	// NullsSortLast is set when NULL values sort after non-NULL values in ascending order (PostgreSQL, Oracle).
+	// MySQL, SQLite and SQL Server sort NULL values first.
+	NullsSortLast bool
+	// SupportILike writes case-insensitive LIKE using ILIKE operator (PostgreSQL),
+	// otherwise both field and value are converted using LOWER function.
+	SupportILike bool
+	// LikeEscapeCharacter is used to escape wildcard of contains, prefix and suffix match,
+	// default to backslash, or ! when BackslashEscape is set.
+	LikeEscapeCharacter byte
+	// BackslashEscape is set when backslash is an escape character in string literal, such as MySQL.
+	BackslashEscape bool
+	// NullSafeEqualOperator is used to write IS NOT DISTINCT FROM, for example <=> for MySQL.
+	NullSafeEqualOperator string
+	// InclusionStrategy is used when IN filter has more values than InclusionThreshold.
+	// Joining a temporary table isn't supported, because it requires a separate statement.
+	InclusionStrategy  InclusionStrategy
+	InclusionThreshold int
+	// InclusionChunkSize is the maximum number of values for each IN filter when using InclusionChunk, default to 1000.
+	InclusionChunkSize int
+	// ArrayValue converts values into a driver value of array when using InclusionArray or array filter, for example pq.Array.
+	// It's required to bind native array, since driver can't bind []any.
+	ArrayValue func(values []any) any
+	// JSON dialect used to write JSON operators, default to PostgreSQL.
+	JSON JSONDialect
+	// ArrayAsJSON is set when the dialect has no native array, array is stored as JSON array and written using JSON dialect.
+	ArrayAsJSON bool
+	// FullText dialect used to write full-text search, default to PostgreSQL.
+	FullText FullTextDialect
+	// FullTextConfig is the PostgreSQL text search configuration such as english, default to simple.
+	// Full-text index is created using the same configuration, so that it can be used by the filter.
+	FullTextConfig string
+}
 
 // Write SQL to buffer.
 func (f Filter) Write(buffer *Buffer, table string, filter rel.FilterQuery, queryWriter QueryWriter) {
@@ -42,6 +94,11 @@ func (f Filter) Write(buffer *Buffer, table string, filter rel.FilterQuery, quer
 		buffer.WriteString(" NOT LIKE ")
 		buffer.WriteValue(filter.Value)
 	case rel.FilterFragmentOp:
+		if clause := filterClause(filter); clause != nil {
+			f.WriteClause(buffer, table, clause, queryWriter)
+			return
+		}
+
 		buffer.WriteString(filter.Field)
 		if !buffer.InlineValues {
 			buffer.AddArguments(filter.Value.([]any)...)
@@ -77,23 +134,11 @@ func (f Filter) WriteLogical(buffer *Buffer, table, op string, inner []rel.Filte
 // WriteComparison SQL to buffer.
 func (f Filter) WriteComparison(buffer *Buffer, table string, filter rel.FilterQuery, queryWriter QueryWriter) {
 	buffer.WriteField(table, filter.Field)
-
-	switch filter.Type {
-	case rel.FilterEqOp:
-		buffer.WriteByte('=')
-	case rel.FilterNeOp:
-		buffer.WriteString("<>")
-	case rel.FilterLtOp:
-		buffer.WriteByte('<')
-	case rel.FilterLteOp:
-		buffer.WriteString("<=")
-	case rel.FilterGtOp:
-		buffer.WriteByte('>')
-	case rel.FilterGteOp:
-		buffer.WriteString(">=")
-	}
+	buffer.WriteString(comparisonOperator(filter.Type))
 
 	switch v := filter.Value.(type) {
+	case Column:
+		buffer.WriteField(table, string(v))
 	case rel.SubQuery:
 		// For warped sub-queries
 		f.WriteSubQuery(buffer, v, queryWriter)
@@ -118,17 +163,124 @@ func (f Filter) WriteInclusion(buffer *Buffer, table string, filter rel.FilterQu
 		} else {
 			buffer.WriteString("1=1")
 		}
+	} else if _, ok := values[0].(rel.Query); (len(values) == 1 && ok) || len(values) <= f.InclusionThreshold {
+		f.writeInclusion(buffer, table, filter.Field, filter.Type, values, queryWriter)
 	} else {
-		buffer.WriteField(table, filter.Field)
+		switch f.InclusionStrategy {
+		case InclusionArray:
+			f.writeInclusionArray(buffer, table, filter.Field, filter.Type, values)
+		case InclusionChunk:
+			f.writeInclusionChunk(buffer, table, filter.Field, filter.Type, values, queryWriter)
+		case InclusionValues:
+			if numericValues(values) {
+				f.writeInclusionInline(buffer, table, filter.Field, filter.Type, values)
+			} else {
+				f.writeInclusion(buffer, table, filter.Field, filter.Type, values, queryWriter)
+			}
+		default:
+			f.writeInclusion(buffer, table, filter.Field, filter.Type, values, queryWriter)
+		}
+	}
+}
 
-		if filter.Type == rel.FilterInOp {
-			buffer.WriteString(" IN ")
-		} else {
-			buffer.WriteString(" NOT IN ")
+func (f Filter) writeInclusion(buffer *Buffer, table, field string, op rel.FilterOp, values []any, queryWriter QueryWriter) {
+	buffer.WriteField(table, field)
+
+	if op == rel.FilterInOp {
+		buffer.WriteString(" IN ")
+	} else {
+		buffer.WriteString(" NOT IN ")
+	}
+
+	f.WriteInclusionValues(buffer, values, queryWriter)
+}
+
+func (f Filter) writeInclusionArray(buffer *Buffer, table, field string, op rel.FilterOp, values []any) {
+	buffer.WriteField(table, field)
+	if op == rel.FilterInOp {
+		buffer.WriteString("=ANY(")
+	} else {
+		buffer.WriteString("<>ALL(")
+	}
+	buffer.WriteValue(f.arrayValue(values))
+	buffer.WriteByte(')')
+}
+
+func (f Filter) writeInclusionChunk(buffer *Buffer, table, field string, op rel.FilterOp, values []any, queryWriter QueryWriter) {
+	var (
+		size  = f.InclusionChunkSize
+		logic = " OR "
+	)
+
+	if size <= 0 {
+		size = 1000
+	}
+
+	if op == rel.FilterNinOp {
+		logic = " AND "
+	}
+
+	buffer.WriteByte('(')
+	for i := 0; i < len(values); i += size {
+		if i > 0 {
+			buffer.WriteString(logic)
+		}
+
+		end := i + size
+		if end > len(values) {
+			end = len(values)
+		}
+
+		f.writeInclusion(buffer, table, field, op, values[i:end], queryWriter)
+	}
+	buffer.WriteByte(')')
+}
+
+func (f Filter) writeInclusionInline(buffer *Buffer, table, field string, op rel.FilterOp, values []any) {
+	inlineValues := buffer.InlineValues
+	buffer.InlineValues = true
+
+	buffer.WriteField(table, field)
+	if op == rel.FilterInOp {
+		buffer.WriteString(" IN (VALUES ")
+	} else {
+		buffer.WriteString(" NOT IN (VALUES ")
+	}
+
+	for i, value := range values {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.WriteByte('(')
+		buffer.WriteValue(value)
+		buffer.WriteByte(')')
+	}
+	buffer.WriteByte(')')
+
+	buffer.InlineValues = inlineValues
+}
+
+// numericValues returns true when all values are finite numbers, which are safe to be written inline.
+func numericValues(values []any) bool {
+	for _, value := range values {
+		if value == nil {
+			return false
 		}
 
-		f.WriteInclusionValues(buffer, values, queryWriter)
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		case reflect.Float32, reflect.Float64:
+			if math.IsNaN(rv.Float()) || math.IsInf(rv.Float(), 0) {
+				return false
+			}
+		default:
+			return false
+		}
 	}
+
+	return true
 }
 
 func (f Filter) WriteInclusionValues(buffer *Buffer, values []any, queryWriter QueryWriter) {
@@ -155,3 +307,380 @@ func (f Filter) WriteSubQuery(buffer *Buffer, sub rel.SubQuery, queryWriter Quer
 	queryWriter.Write(buffer, sub.Query)
 	buffer.WriteByte(')')
 }
+
+// WriteClause writes filter expression carried by fragment filter to buffer.
+func (f Filter) WriteClause(buffer *Buffer, table string, clause any, queryWriter QueryWriter) {
+	switch v := clause.(type) {
+	case Keyset:
+		f.WriteKeyset(buffer, table, v)
+	case ExistsFilter:
+		if v.Not {
+			buffer.WriteString("NOT ")
+		}
+		f.WriteSubQuery(buffer, rel.SubQuery{Prefix: "EXISTS ", Query: v.Query}, queryWriter)
+	case LikeFilter:
+		f.WriteLike(buffer, table, v)
+	case BetweenFilter:
+		f.WriteBetween(buffer, table, v)
+	case DistinctFilter:
+		f.WriteDistinct(buffer, table, v)
+	case TupleFilter:
+		f.WriteTuple(buffer, table, v)
+	case JSONFilter, JSONContainsFilter, JSONHasPathFilter:
+		f.WriteJSON(buffer, table, v)
+	case MatchFilter:
+		f.WriteMatch(buffer, table, v)
+	case ArrayFilter:
+		f.WriteArray(buffer, table, v)
+	}
+}
+
+// WriteBetween SQL to buffer.
+func (f Filter) WriteBetween(buffer *Buffer, table string, between BetweenFilter) {
+	buffer.WriteField(table, between.Field)
+	if between.Not {
+		buffer.WriteString(" NOT BETWEEN ")
+	} else {
+		buffer.WriteString(" BETWEEN ")
+	}
+	f.writeOperand(buffer, table, between.Low)
+	buffer.WriteString(" AND ")
+	f.writeOperand(buffer, table, between.High)
+}
+
+// WriteDistinct SQL to buffer.
+func (f Filter) WriteDistinct(buffer *Buffer, table string, distinct DistinctFilter) {
+	if f.NullSafeEqualOperator != "" {
+		if !distinct.Not {
+			buffer.WriteString("NOT ")
+		}
+		buffer.WriteByte('(')
+		buffer.WriteField(table, distinct.Field)
+		buffer.WriteString(f.NullSafeEqualOperator)
+		f.writeOperand(buffer, table, distinct.Value)
+		buffer.WriteByte(')')
+		return
+	}
+
+	buffer.WriteField(table, distinct.Field)
+	if distinct.Not {
+		buffer.WriteString(" IS NOT DISTINCT FROM ")
+	} else {
+		buffer.WriteString(" IS DISTINCT FROM ")
+	}
+	f.writeOperand(buffer, table, distinct.Value)
+}
+
+// WriteTuple SQL to buffer.
+func (f Filter) WriteTuple(buffer *Buffer, table string, tuple TupleFilter) {
+	for _, row := range tuple.Values {
+		if len(row) != len(tuple.Fields) {
+			fail("%w: tuple values doesn't match fields", ErrInvalidArgument)
+		}
+	}
+
+	if tuple.Op == rel.FilterInOp || tuple.Op == rel.FilterNinOp {
+		if len(tuple.Values) == 0 {
+			if tuple.Op == rel.FilterInOp {
+				buffer.WriteString("1=0")
+			} else {
+				buffer.WriteString("1=1")
+			}
+			return
+		}
+
+		f.writeTupleFields(buffer, table, tuple.Fields)
+		if tuple.Op == rel.FilterInOp {
+			buffer.WriteString(" IN (")
+		} else {
+			buffer.WriteString(" NOT IN (")
+		}
+
+		for i, row := range tuple.Values {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			f.writeTupleValues(buffer, table, row)
+		}
+		buffer.WriteByte(')')
+		return
+	}
+
+	f.writeTupleFields(buffer, table, tuple.Fields)
+	buffer.WriteString(comparisonOperator(tuple.Op))
+	f.writeTupleValues(buffer, table, tuple.Values[0])
+}
+
+func (f Filter) writeTupleFields(buffer *Buffer, table string, fields []string) {
+	buffer.WriteByte('(')
+	for i, field := range fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.WriteField(table, field)
+	}
+	buffer.WriteByte(')')
+}
+
+func (f Filter) writeTupleValues(buffer *Buffer, table string, values []any) {
+	buffer.WriteByte('(')
+	for i, value := range values {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		f.writeOperand(buffer, table, value)
+	}
+	buffer.WriteByte(')')
+}
+
+// writeOperand writes column reference or value placeholder.
+func (f Filter) writeOperand(buffer *Buffer, table string, value any) {
+	if column, ok := value.(Column); ok {
+		buffer.WriteField(table, string(column))
+	} else {
+		buffer.WriteValue(value)
+	}
+}
+
+// WriteLike SQL to buffer.
+func (f Filter) WriteLike(buffer *Buffer, table string, like LikeFilter) {
+	var (
+		lower   = like.CaseInsensitive && !f.SupportILike
+		op      = " LIKE "
+		escape  = f.likeEscapeCharacter()
+		pattern = like.pattern(escape)
+	)
+
+	switch {
+	case like.CaseInsensitive && !lower && like.Not:
+		op = " NOT ILIKE "
+	case like.CaseInsensitive && !lower:
+		op = " ILIKE "
+	case like.Not:
+		op = " NOT LIKE "
+	}
+
+	if lower {
+		buffer.WriteString("LOWER(")
+		buffer.WriteField(table, like.Field)
+		buffer.WriteString(")")
+		buffer.WriteString(op)
+		buffer.WriteString("LOWER(")
+		buffer.WriteValue(pattern)
+		buffer.WriteString(")")
+	} else {
+		buffer.WriteField(table, like.Field)
+		buffer.WriteString(op)
+		buffer.WriteValue(pattern)
+	}
+
+	if like.Match != LikePattern {
+		literal := string(escape)
+		if escape == '\\' && f.BackslashEscape {
+			literal = `\\`
+		}
+
+		buffer.WriteString(" ESCAPE ")
+		buffer.WriteString(buffer.Quoter.Value(literal))
+	}
+}
+
+func (f Filter) likeEscapeCharacter() byte {
+	switch {
+	case f.LikeEscapeCharacter != 0:
+		return f.LikeEscapeCharacter
+	case f.BackslashEscape:
+		return '!'
+	}
+
+	return LikeEscapeCharacter
+}
+
+// WriteKeyset SQL to buffer.
+func (f Filter) WriteKeyset(buffer *Buffer, table string, keyset Keyset) {
+	if len(keyset.Sorts) != len(keyset.Values) {
+		fail("%w: keyset values doesn't match sort fields", ErrInvalidArgument)
+	}
+
+	if len(keyset.Sorts) == 0 {
+		buffer.WriteString("1=1")
+		return
+	}
+
+	if keyset.RowValue && keysetRowValue(keyset) {
+		f.writeKeysetRowValue(buffer, table, keyset)
+		return
+	}
+
+	// (a>? OR (a=? AND b>?) OR (a=? AND b=? AND c>?))
+	var (
+		n = 0
+	)
+
+	buffer.WriteByte('(')
+	for i := range keyset.Sorts {
+		if !f.keysetHasAfter(keyset.Sorts[i], keyset.Values[i]) {
+			continue
+		}
+
+		if n > 0 {
+			buffer.WriteString(" OR ")
+		}
+
+		if i > 0 {
+			buffer.WriteByte('(')
+		}
+
+		for j := 0; j < i; j++ {
+			f.writeKeysetEqual(buffer, table, keyset.Sorts[j].Field, keyset.Values[j])
+			buffer.WriteString(" AND ")
+		}
+		f.writeKeysetAfter(buffer, table, keyset.Sorts[i], keyset.Values[i])
+
+		if i > 0 {
+			buffer.WriteByte(')')
+		}
+		n++
+	}
+
+	if n == 0 {
+		// the last row is the last possible position.
+		buffer.WriteString("1=0")
+	}
+	buffer.WriteByte(')')
+}
+
+func (f Filter) writeKeysetRowValue(buffer *Buffer, table string, keyset Keyset) {
+	fields := make([]string, len(keyset.Sorts))
+	for i, sort := range keyset.Sorts {
+		fields[i] = sort.Field
+	}
+
+	op := rel.FilterGtOp
+	if keyset.Sorts[0].Desc() {
+		op = rel.FilterLtOp
+	}
+
+	f.WriteTuple(buffer, table, TupleFilter{Fields: fields, Op: op, Values: [][]any{keyset.Values}})
+}
+
+// nullsAfter reports whether NULL values come after non-NULL values for given sort direction.
+func (f Filter) nullsAfter(sort rel.SortQuery) bool {
+	switch nullsOrder(sort) {
+	case sortNullsFirst:
+		return false
+	case sortNullsLast:
+		return true
+	}
+
+	return f.NullsSortLast == sort.Asc()
+}
+
+func (f Filter) keysetHasAfter(sort rel.SortQuery, value any) bool {
+	return value != nil || !f.nullsAfter(sort)
+}
+
+func (f Filter) writeKeysetEqual(buffer *Buffer, table, field string, value any) {
+	buffer.WriteField(table, field)
+	if value == nil {
+		buffer.WriteString(" IS NULL")
+	} else {
+		buffer.WriteByte('=')
+		buffer.WriteValue(value)
+	}
+}
+
+func (f Filter) writeKeysetAfter(buffer *Buffer, table string, sort rel.SortQuery, value any) {
+	if value == nil {
+		// only reachable when NULL values come first.
+		buffer.WriteField(table, sort.Field)
+		buffer.WriteString(" IS NOT NULL")
+		return
+	}
+
+	nullsAfter := f.nullsAfter(sort)
+	if nullsAfter {
+		buffer.WriteByte('(')
+	}
+
+	buffer.WriteField(table, sort.Field)
+	if sort.Asc() {
+		buffer.WriteByte('>')
+	} else {
+		buffer.WriteByte('<')
+	}
+	buffer.WriteValue(value)
+
+	if nullsAfter {
+		buffer.WriteString(" OR ")
+		buffer.WriteField(table, sort.Field)
+		buffer.WriteString(" IS NULL)")
+	}
+}
+
+func comparisonOperator(op rel.FilterOp) string {
+	switch op {
+	case rel.FilterEqOp:
+		return "="
+	case rel.FilterNeOp:
+		return "<>"
+	case rel.FilterLtOp:
+		return "<"
+	case rel.FilterLteOp:
+		return "<="
+	case rel.FilterGtOp:
+		return ">"
+	case rel.FilterGteOp:
+		return ">="
+	}
+
+	return ""
+}
+
+func keysetRowValue(keyset Keyset) bool {
+	for i := range keyset.Sorts {
+		if keyset.Values[i] == nil || keyset.Sorts[i].Asc() != keyset.Sorts[0].Asc() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filterClause returns filter expression defined by this package that is carried by fragment filter.
+//
+// rel.FilterQuery only supports operators defined by rel, so filters such as keyset, EXISTS, BETWEEN and JSON path
+// are carried as the only value of a fragment filter and written by Filter.WriteClause instead of the fragment.
+func filterClause(filter rel.FilterQuery) any {
+	values, ok := filter.Value.([]any)
+	if !ok || len(values) != 1 {
+		return nil
+	}
+
+	switch v := values[0].(type) {
+	case Keyset:
+		return v
+	case ExistsFilter:
+		return v
+	case LikeFilter:
+		return v
+	case BetweenFilter:
+		return v
+	case DistinctFilter:
+		return v
+	case TupleFilter:
+		return v
+	case JSONFilter:
+		return v
+	case JSONContainsFilter:
+		return v
+	case JSONHasPathFilter:
+		return v
+	case MatchFilter:
+		return v
+	case ArrayFilter:
+		return v
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"math"
 	"testing"
 
 	"github.com/go-rel/rel"
@@ -373,3 +374,520 @@ func TestFilter_Write_ordinal(t *testing.T) {
 		})
 	}
 }
+
+func TestFilter_WriteKeyset(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory}
+		sorts         = []rel.SortQuery{rel.SortDesc("created_at"), rel.SortAsc("id")}
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		filter Filter
+		keyset rel.FilterQuery
+	}{
+		{
+			result: "((`created_at`<? OR `created_at` IS NULL) OR (`created_at`=? AND `id`>?))",
+			args:   []any{"2021-01-01", "2021-01-01", 10},
+			keyset: KeysetAfter(sorts, "2021-01-01", 10),
+		},
+		{
+			result: "(`created_at`<? OR (`created_at`=? AND `id`<?))",
+			args:   []any{"2021-01-01", "2021-01-01", 10},
+			filter: Filter{NullsSortLast: true},
+			keyset: KeysetAfter([]rel.SortQuery{rel.SortDesc("created_at"), rel.SortDesc("id")}, "2021-01-01", 10),
+		},
+		{
+			result: "(`created_at` IS NOT NULL OR (`created_at` IS NULL AND `id`>?))",
+			args:   []any{10},
+			keyset: KeysetAfter([]rel.SortQuery{rel.SortAsc("created_at"), rel.SortAsc("id")}, nil, 10),
+		},
+		{
+			result: "((`created_at` IS NULL AND (`id`>? OR `id` IS NULL)))",
+			args:   []any{10},
+			filter: Filter{NullsSortLast: true},
+			keyset: KeysetAfter([]rel.SortQuery{rel.SortAsc("created_at"), rel.SortAsc("id")}, nil, 10),
+		},
+		{
+			result: "(1=0)",
+			filter: Filter{NullsSortLast: true},
+			keyset: KeysetAfter([]rel.SortQuery{rel.SortAsc("deleted_at")}, nil),
+		},
+		{
+			result: "(`created_at`,`id`)>(?,?)",
+			args:   []any{"2021-01-01", 10},
+			keyset: KeysetAfterRowValue([]rel.SortQuery{rel.SortAsc("created_at"), rel.SortAsc("id")}, "2021-01-01", 10),
+		},
+		{
+			result: "(`created_at`,`id`)<(?,?)",
+			args:   []any{"2021-01-01", 10},
+			keyset: KeysetAfterRowValue([]rel.SortQuery{rel.SortDesc("created_at"), rel.SortDesc("id")}, "2021-01-01", 10),
+		},
+		{
+			result: "((`created_at`<? OR `created_at` IS NULL) OR (`created_at`=? AND `id`>?))",
+			args:   []any{"2021-01-01", "2021-01-01", 10},
+			filter: Filter{},
+			keyset: KeysetAfterRowValue(sorts, "2021-01-01", 10),
+		},
+		{
+			result: "(`created_at`>? OR (`created_at`=? AND (`id`<? OR `id` IS NULL)))",
+			args:   []any{"2021-01-01", "2021-01-01", 10},
+			keyset: KeysetAfter([]rel.SortQuery{NullsFirst(rel.SortAsc("created_at")), NullsLast(rel.SortDesc("id"))}, "2021-01-01", 10),
+		},
+		{
+			result: "1=1",
+			keyset: KeysetAfter(nil),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				buffer = bufferFactory.Create()
+			)
+
+			test.filter.Write(&buffer, "", test.keyset, queryBuilder)
+
+			assert.Equal(t, test.result, buffer.String())
+			assert.Equal(t, test.args, buffer.Arguments())
+		})
+	}
+}
+
+func TestFilter_WriteKeyset_query(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, Filter: Filter{NullsSortLast: true}}
+		sorts         = []rel.SortQuery{rel.SortAsc("score"), rel.SortAsc("id")}
+		query         = rel.Build("users", rel.Where(where.Eq("active", true), KeysetAfter(sorts, 5, 10)), sorts[0], sorts[1], rel.Limit(10))
+		result, args  = queryBuilder.Build(query)
+	)
+
+	assert.Equal(t, "SELECT \"users\".* FROM \"users\" WHERE (\"users\".\"active\"=$1 AND ((\"users\".\"score\">$2 OR \"users\".\"score\" IS NULL) OR (\"users\".\"score\"=$3 AND (\"users\".\"id\">$4 OR \"users\".\"id\" IS NULL)))) ORDER BY \"users\".\"score\" ASC, \"users\".\"id\" ASC LIMIT 10;", result)
+	assert.Equal(t, []any{true, 5, 5, 10}, args)
+}
+
+func TestFilter_WriteKeyset_mismatch(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`"}}
+		buffer        = bufferFactory.Create()
+		keyset        rel.FilterQuery
+	)
+
+	// mismatch is reported when the filter is written by the adapter, so that it's returned as error.
+	assert.NotPanics(t, func() {
+		keyset = KeysetAfter([]rel.SortQuery{rel.SortAsc("id")})
+	})
+
+	assert.PanicsWithError(t, "invalid argument: keyset values doesn't match sort fields", func() {
+		Filter{}.Write(&buffer, "users", keyset, nil)
+	})
+}
+
+func TestFilter_Write_exists(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, Filter: Filter{}}
+		orders        = rel.Select("1").From("orders").Where(where.Eq("orders.user_id", Column("users.id")), where.Gt("total", 100))
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		query  rel.Query
+	}{
+		{
+			result: "SELECT \"users\".* FROM \"users\" WHERE (\"users\".\"active\"=$1 AND EXISTS (SELECT 1 FROM \"orders\" WHERE (\"orders\".\"user_id\"=\"users\".\"id\" AND \"orders\".\"total\">$2)));",
+			args:   []any{true, 100},
+			query:  rel.From("users").Where(where.Eq("active", true), Exists(orders)),
+		},
+		{
+			result: "SELECT \"users\".* FROM \"users\" WHERE NOT EXISTS (SELECT 1 FROM \"orders\" WHERE (\"orders\".\"user_id\"=\"users\".\"id\" AND \"orders\".\"total\">$1));",
+			args:   []any{100},
+			query:  rel.From("users").Where(NotExists(orders)),
+		},
+		{
+			result: "SELECT \"users\".* FROM \"users\" WHERE \"users\".\"updated_at\">\"users\".\"created_at\";",
+			query:  rel.From("users").Where(where.Gt("updated_at", Column("created_at"))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result, args := queryBuilder.Build(test.query)
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
+func TestFilter_WriteLike(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory}
+		postgres      = Filter{SupportILike: true}
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		filter Filter
+		like   rel.FilterQuery
+	}{
+		{
+			result: "\"users\".\"name\" ILIKE $1",
+			args:   []any{"jo%"},
+			filter: postgres,
+			like:   ILike("name", "jo%"),
+		},
+		{
+			result: "\"users\".\"name\" NOT ILIKE $1",
+			args:   []any{"jo%"},
+			filter: postgres,
+			like:   NotILike("name", "jo%"),
+		},
+		{
+			result: "LOWER(\"users\".\"name\") LIKE LOWER($1)",
+			args:   []any{"jo%"},
+			like:   ILike("name", "jo%"),
+		},
+		{
+			result: "LOWER(\"users\".\"name\") NOT LIKE LOWER($1)",
+			args:   []any{"jo%"},
+			like:   NotILike("name", "jo%"),
+		},
+		{
+			result: "\"users\".\"discount\" ILIKE $1 ESCAPE '\\'",
+			args:   []any{"%50\\%%"},
+			filter: postgres,
+			like:   IContains("discount", "50%"),
+		},
+		{
+			result: "LOWER(\"users\".\"code\") LIKE LOWER($1) ESCAPE '\\'",
+			args:   []any{"a\\_b%"},
+			like:   IStartsWith("code", "a_b"),
+		},
+		{
+			result: "LOWER(\"users\".\"path\") LIKE LOWER($1) ESCAPE '\\'",
+			args:   []any{"%\\\\tmp"},
+			like:   IEndsWith("path", "\\tmp"),
+		},
+		{
+			result: "\"users\".\"discount\" LIKE $1 ESCAPE '\\'",
+			args:   []any{"%50\\%%"},
+			like:   Contains("discount", "50%"),
+		},
+		{
+			result: "\"users\".\"discount\" NOT LIKE $1 ESCAPE '\\'",
+			args:   []any{"%50\\%%"},
+			like:   NotContains("discount", "50%"),
+		},
+		{
+			result: "\"users\".\"code\" LIKE $1 ESCAPE '!'",
+			args:   []any{"a!_b!!%"},
+			filter: Filter{LikeEscapeCharacter: '!'},
+			like:   StartsWith("code", "a_b!"),
+		},
+		{
+			result: "\"users\".\"path\" LIKE $1 ESCAPE '\\'",
+			args:   []any{"%.tar\\_gz"},
+			like:   EndsWith("path", ".tar_gz"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				buffer = bufferFactory.Create()
+			)
+
+			test.filter.Write(&buffer, "users", test.like, queryBuilder)
+
+			assert.Equal(t, test.result, buffer.String())
+			assert.Equal(t, test.args, buffer.Arguments())
+		})
+	}
+}
+
+func TestFilter_WriteLike_mysql(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory}
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		filter Filter
+		like   rel.FilterQuery
+	}{
+		{
+			result: "`users`.`discount` LIKE ? ESCAPE '!'",
+			args:   []any{"%10!%%"},
+			filter: Filter{BackslashEscape: true},
+			like:   Contains("discount", "10%"),
+		},
+		{
+			result: "LOWER(`users`.`path`) LIKE LOWER(?) ESCAPE '!'",
+			args:   []any{`C:\%`},
+			filter: Filter{BackslashEscape: true},
+			like:   IStartsWith("path", `C:\`),
+		},
+		{
+			result: "`users`.`code` LIKE ? ESCAPE '\\\\'",
+			args:   []any{`%a\_b`},
+			filter: Filter{BackslashEscape: true, LikeEscapeCharacter: '\\'},
+			like:   EndsWith("code", "a_b"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			buffer := bufferFactory.Create()
+			test.filter.Write(&buffer, "users", test.like, queryBuilder)
+
+			assert.Equal(t, test.result, buffer.String())
+			assert.Equal(t, test.args, buffer.Arguments())
+		})
+	}
+}
+
+func TestFilter_WriteLike_inlineValues(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{InlineValues: true, Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory}
+		buffer        = bufferFactory.Create()
+	)
+
+	Filter{LikeEscapeCharacter: '!'}.Write(&buffer, "", Contains("name", "o'neil_50%"), queryBuilder)
+
+	assert.Equal(t, "`name` LIKE '%o''neil!_50!%%' ESCAPE '!'", buffer.String())
+	assert.Nil(t, buffer.Arguments())
+}
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, "john", EscapeLike("john"))
+	assert.Equal(t, "50\\%", EscapeLike("50%"))
+	assert.Equal(t, "a\\_b\\\\c", EscapeLike("a_b\\c"))
+	assert.Equal(t, "a!_b\\c!!", EscapeLikeWith("a_b\\c!", '!'))
+}
+
+func TestFilter_Write_comparison(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory}
+		mysql         = Filter{NullSafeEqualOperator: "<=>"}
+		keys          = []string{"primary1", "primary2"}
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		filter Filter
+		query  rel.FilterQuery
+	}{
+		{
+			result: "\"age\" BETWEEN $1 AND $2",
+			args:   []any{10, 20},
+			query:  Between("age", 10, 20),
+		},
+		{
+			result: "\"age\" NOT BETWEEN $1 AND \"max_age\"",
+			args:   []any{10},
+			query:  NotBetween("age", 10, Column("max_age")),
+		},
+		{
+			result: "\"note\" IS DISTINCT FROM $1",
+			args:   []any{nil},
+			query:  IsDistinctFrom("note", nil),
+		},
+		{
+			result: "\"note\" IS NOT DISTINCT FROM $1",
+			args:   []any{"a"},
+			query:  IsNotDistinctFrom("note", "a"),
+		},
+		{
+			result: "NOT (\"note\"<=>$1)",
+			args:   []any{nil},
+			filter: mysql,
+			query:  IsDistinctFrom("note", nil),
+		},
+		{
+			result: "(\"note\"<=>\"old_note\")",
+			filter: mysql,
+			query:  IsNotDistinctFrom("note", Column("old_note")),
+		},
+		{
+			result: "(\"primary1\",\"primary2\")=($1,$2)",
+			args:   []any{1, 2},
+			query:  TupleEq(keys, 1, 2),
+		},
+		{
+			result: "(\"primary1\",\"primary2\")<>($1,$2)",
+			args:   []any{1, 2},
+			query:  TupleNe(keys, 1, 2),
+		},
+		{
+			result: "(\"primary1\",\"primary2\")>($1,$2)",
+			args:   []any{1, 2},
+			query:  TupleGt(keys, 1, 2),
+		},
+		{
+			result: "(\"primary1\",\"primary2\")>=($1,$2)",
+			args:   []any{1, 2},
+			query:  TupleGte(keys, 1, 2),
+		},
+		{
+			result: "(\"primary1\",\"primary2\")<($1,$2)",
+			args:   []any{1, 2},
+			query:  TupleLt(keys, 1, 2),
+		},
+		{
+			result: "(\"primary1\",\"primary2\")<=($1,$2)",
+			args:   []any{1, 2},
+			query:  TupleLte(keys, 1, 2),
+		},
+		{
+			result: "(\"primary1\",\"primary2\") IN (($1,$2),($3,$4))",
+			args:   []any{1, 2, 3, 4},
+			query:  TupleIn(keys, []any{1, 2}, []any{3, 4}),
+		},
+		{
+			result: "(\"primary1\",\"primary2\") NOT IN (($1,$2))",
+			args:   []any{1, 2},
+			query:  TupleNin(keys, []any{1, 2}),
+		},
+		{
+			result: "1=0",
+			query:  TupleIn(keys),
+		},
+		{
+			result: "1=1",
+			query:  TupleNin(keys),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				buffer = bufferFactory.Create()
+			)
+
+			test.filter.Write(&buffer, "", test.query, queryBuilder)
+
+			assert.Equal(t, test.result, buffer.String())
+			assert.Equal(t, test.args, buffer.Arguments())
+		})
+	}
+}
+
+func TestFilter_WriteTuple_mismatch(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`"}}
+		buffer        = bufferFactory.Create()
+		tuple         rel.FilterQuery
+	)
+
+	assert.NotPanics(t, func() {
+		tuple = TupleIn([]string{"primary1", "primary2"}, []any{1})
+	})
+
+	assert.PanicsWithError(t, "invalid argument: tuple values doesn't match fields", func() {
+		Filter{}.Write(&buffer, "users", tuple, nil)
+	})
+}
+
+func TestFilter_WriteInclusion_strategy(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory}
+		arrayValue    = func(values []any) any { return len(values) }
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		filter Filter
+		query  rel.FilterQuery
+	}{
+		{
+			result: "\"id\" IN ($1,$2,$3)",
+			args:   []any{1, 2, 3},
+			filter: Filter{InclusionStrategy: InclusionArray, InclusionThreshold: 3},
+			query:  where.In("id", 1, 2, 3),
+		},
+		{
+			result: "\"id\"=ANY($1)",
+			args:   []any{[]any{1, 2, 3}},
+			filter: Filter{InclusionStrategy: InclusionArray, InclusionThreshold: 2, ArrayValue: func(values []any) any { return values }},
+			query:  where.In("id", 1, 2, 3),
+		},
+		{
+			result: "\"id\"<>ALL($1)",
+			args:   []any{3},
+			filter: Filter{InclusionStrategy: InclusionArray, ArrayValue: arrayValue},
+			query:  where.Nin("id", 1, 2, 3),
+		},
+		{
+			result: "(\"id\" IN ($1,$2) OR \"id\" IN ($3))",
+			args:   []any{1, 2, 3},
+			filter: Filter{InclusionStrategy: InclusionChunk, InclusionChunkSize: 2},
+			query:  where.In("id", 1, 2, 3),
+		},
+		{
+			result: "(\"id\" NOT IN ($1,$2) AND \"id\" NOT IN ($3,$4))",
+			args:   []any{1, 2, 3, 4},
+			filter: Filter{InclusionStrategy: InclusionChunk, InclusionChunkSize: 2},
+			query:  where.Nin("id", 1, 2, 3, 4),
+		},
+		{
+			result: "(\"id\" IN ($1,$2) OR \"id\" IN ($3,$4))",
+			args:   []any{1, 2, 3, 4},
+			filter: Filter{InclusionStrategy: InclusionChunk, InclusionChunkSize: 2, ArrayValue: arrayValue},
+			query:  where.In("id", 1, 2, 3, 4),
+		},
+		{
+			result: "\"name\" IN ($1,$2)",
+			args:   []any{"a", "b'c"},
+			filter: Filter{InclusionStrategy: InclusionValues},
+			query:  where.In("name", "a", "b'c"),
+		},
+		{
+			result: "\"score\" IN ($1,$2)",
+			args:   []any{1.5, math.Inf(1)},
+			filter: Filter{InclusionStrategy: InclusionValues},
+			query:  where.In("score", 1.5, math.Inf(1)),
+		},
+		{
+			result: "\"score\" IN (VALUES (1.5),(2))",
+			filter: Filter{InclusionStrategy: InclusionValues},
+			query:  where.In("score", 1.5, uint8(2)),
+		},
+		{
+			result: "\"id\" NOT IN (VALUES (1),(2))",
+			filter: Filter{InclusionStrategy: InclusionValues},
+			query:  where.Nin("id", 1, 2),
+		},
+		{
+			result: "\"id\" IN (SELECT \"users\".\"id\" FROM \"users\")",
+			filter: Filter{InclusionStrategy: InclusionValues},
+			query:  where.In("id", rel.Select("id").From("users")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				buffer = bufferFactory.Create()
+			)
+
+			test.filter.Write(&buffer, "", test.query, queryBuilder)
+
+			assert.Equal(t, test.result, buffer.String())
+			assert.Equal(t, test.args, buffer.Arguments())
+			assert.False(t, buffer.InlineValues)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package builder
+
+import (
+	"strings"
+
+	"github.com/go-rel/rel"
+)
+
+// FullTextDialect defines how full-text search is written.
+type FullTextDialect int
+
+const (
+	// FullTextPostgreSQL matches to_tsvector against plainto_tsquery, indexed using GIN index.
+	FullTextPostgreSQL FullTextDialect = iota
+	// FullTextMySQL writes MATCH ... AGAINST in natural language mode, indexed using FULLTEXT index.
+	FullTextMySQL
+	// FullTextSQLite writes MATCH against FTS5 virtual table.
+	FullTextSQLite
+)
+
+// FullTextIndex is an index option that creates full-text index of the columns instead of regular index.
+//
+//	schema.CreateIndex("posts", "posts_search", []string{"title", "body"}, builder.FullTextIndex)
+var FullTextIndex = rel.Options("FULLTEXT")
+
+// DefaultFullTextConfig is the PostgreSQL text search configuration used when none is configured.
+const DefaultFullTextConfig = "simple"
+
+// MatchFilter filters rows which fields match full-text search query.
+//
+// Index is the FTS5 virtual table created by full-text index, and only used by SQLite.
+// When it's empty, the queried table is expected to be FTS5 virtual table itself.
+type MatchFilter struct {
+	Fields []string
+	Query  string
+	Index  string
+}
+
+// Match filters rows which fields match full-text search query written in natural language.
+func Match(fields []string, query string) rel.FilterQuery {
+	return matchFilterQuery(MatchFilter{Fields: fields, Query: query})
+}
+
+// MatchIndex is like Match, but searches using FTS5 virtual table created by full-text index on SQLite.
+func MatchIndex(index string, fields []string, query string) rel.FilterQuery {
+	return matchFilterQuery(MatchFilter{Fields: fields, Query: query, Index: index})
+}
+
+func matchFilterQuery(match MatchFilter) rel.FilterQuery {
+	return rel.FilterQuery{
+		Type:  rel.FilterFragmentOp,
+		Field: "MATCH " + strings.Join(match.Fields, ","),
+		Value: []any{match},
+	}
+}
+
+// MatchRankField is a select field of relevance score of full-text search, higher is more relevant.
+// Match must be a full-text match filter, such as the one returned by Match.
+type MatchRankField struct {
+	Match rel.FilterQuery
+	Alias string
+}
+
+// matchFilter returns full-text match filter to be ranked.
+func (mr MatchRankField) matchFilter() MatchFilter {
+	mf, ok := filterClause(mr.Match).(MatchFilter)
+	if !ok {
+		fail("%w: SelectRank requires full-text match filter", ErrInvalidArgument)
+	}
+
+	return mf
+}
+
+// SelectRank selects relevance score of full-text match filter as alias, which can be used to sort the result.
+//
+//	rel.Build("posts", builder.SelectRank(match, "rank"), rel.Where(match), rel.SortDesc("rank"))
+func SelectRank(match rel.FilterQuery, alias string) rel.JoinQuery {
+	return rel.JoinQuery{
+		Mode:      "SELECT",
+		Table:     alias,
+		Arguments: []any{MatchRankField{Match: match, Alias: alias}},
+	}
+}
+
+// WriteMatch writes full-text match filter to buffer.
+func (f Filter) WriteMatch(buffer *Buffer, table string, match MatchFilter) {
+	switch f.FullText {
+	case FullTextMySQL:
+		f.writeMySQLMatch(buffer, table, match)
+	case FullTextSQLite:
+		// FTS5 query without any phrase is a syntax error, so it's written as filter that matches nothing.
+		if len(strings.Fields(match.Query)) == 0 {
+			buffer.WriteString("1=0")
+			return
+		}
+
+		if match.Index == "" {
+			buffer.WriteString(tableAlias(buffer, table))
+			buffer.WriteString(" MATCH ")
+			buffer.WriteValue(sqliteMatchQuery(match))
+			return
+		}
+
+		buffer.WriteString(tableAlias(buffer, table))
+		buffer.WriteString(".rowid IN (SELECT rowid FROM ")
+		buffer.WriteTable(match.Index)
+		buffer.WriteString(" WHERE ")
+		buffer.WriteTable(match.Index)
+		buffer.WriteString(" MATCH ")
+		buffer.WriteValue(sqliteMatchQuery(match))
+		buffer.WriteByte(')')
+	default:
+		f.WriteTSVector(buffer, table, match.Fields)
+		buffer.WriteString(" @@ ")
+		f.writeTSQuery(buffer, match.Query)
+	}
+}
+
+// WriteMatchRank writes relevance score of full-text match to buffer.
+func (f Filter) WriteMatchRank(buffer *Buffer, table string, match MatchFilter) {
+	switch f.FullText {
+	case FullTextMySQL:
+		f.writeMySQLMatch(buffer, table, match)
+	case FullTextSQLite:
+		if len(strings.Fields(match.Query)) == 0 {
+			buffer.WriteString("0")
+			return
+		}
+
+		// bm25 returns lower value for better match.
+		if match.Index == "" {
+			buffer.WriteString("-bm25(")
+			buffer.WriteString(tableAlias(buffer, table))
+			buffer.WriteByte(')')
+			return
+		}
+
+		buffer.WriteString("(SELECT -bm25(")
+		buffer.WriteTable(match.Index)
+		buffer.WriteString(") FROM ")
+		buffer.WriteTable(match.Index)
+		buffer.WriteString(" WHERE ")
+		buffer.WriteTable(match.Index)
+		buffer.WriteString(" MATCH ")
+		buffer.WriteValue(sqliteMatchQuery(match))
+		buffer.WriteString(" AND ")
+		buffer.WriteTable(match.Index)
+		buffer.WriteString(".rowid=")
+		buffer.WriteString(tableAlias(buffer, table))
+		buffer.WriteString(".rowid)")
+	default:
+		buffer.WriteString("ts_rank(")
+		f.WriteTSVector(buffer, table, match.Fields)
+		buffer.WriteString(", ")
+		f.writeTSQuery(buffer, match.Query)
+		buffer.WriteByte(')')
+	}
+}
+
+// WriteTSVector writes PostgreSQL text search document of fields to buffer.
+// The same expression is used by full-text index, so that it can be used by the filter.
+func (f Filter) WriteTSVector(buffer *Buffer, table string, fields []string) {
+	buffer.WriteString("to_tsvector(")
+	buffer.WriteString(buffer.Quoter.Value(f.fullTextConfig()))
+	buffer.WriteString(", ")
+
+	if len(fields) == 1 {
+		buffer.WriteField(table, fields[0])
+	} else {
+		for i, field := range fields {
+			if i > 0 {
+				buffer.WriteString(" || ' ' || ")
+			}
+			buffer.WriteString("coalesce(")
+			buffer.WriteField(table, field)
+			buffer.WriteString(", '')")
+		}
+	}
+
+	buffer.WriteByte(')')
+}
+
+func (f Filter) writeTSQuery(buffer *Buffer, query string) {
+	buffer.WriteString("plainto_tsquery(")
+	buffer.WriteString(buffer.Quoter.Value(f.fullTextConfig()))
+	buffer.WriteString(", ")
+	buffer.WriteValue(query)
+	buffer.WriteByte(')')
+}
+
+func (f Filter) writeMySQLMatch(buffer *Buffer, table string, match MatchFilter) {
+	buffer.WriteString("MATCH(")
+	for i, field := range match.Fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.WriteField(table, field)
+	}
+	buffer.WriteString(") AGAINST(")
+	buffer.WriteValue(match.Query)
+	buffer.WriteString(" IN NATURAL LANGUAGE MODE)")
+}
+
+func (f Filter) fullTextConfig() string {
+	if f.FullTextConfig == "" {
+		return DefaultFullTextConfig
+	}
+
+	return f.FullTextConfig
+}
+
+// sqliteMatchQuery converts natural language query into FTS5 query,
+// each word is quoted as a string, so that it's not parsed as FTS5 operator.
+func sqliteMatchQuery(match MatchFilter) string {
+	var (
+		builder strings.Builder
+	)
+
+	if len(match.Fields) > 0 {
+		builder.WriteByte('{')
+		for i, field := range match.Fields {
+			if i > 0 {
+				builder.WriteByte(' ')
+			}
+			builder.WriteString(field)
+		}
+		builder.WriteString("} : (")
+	}
+
+	for i, word := range strings.Fields(match.Query) {
+		if i > 0 {
+			builder.WriteByte(' ')
+		}
+		builder.WriteByte('"')
+		builder.WriteString(strings.ReplaceAll(word, `"`, `""`))
+		builder.WriteByte('"')
+	}
+
+	if len(match.Fields) > 0 {
+		builder.WriteByte(')')
+	}
+
+	return builder.String()
+}
+
+// tableAlias returns quoted alias of table, or the table name itself when it has no alias.
+func tableAlias(buffer *Buffer, table string) string {
+	_, alias := extractAlias(table)
+	return buffer.Quoter.ID(alias)
+}
@@ -0,0 +1,152 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery_Build_fullText(t *testing.T) {
+	var (
+		pgBufferFactory     = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", IDSuffixEscapeChar: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		mysqlBufferFactory  = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		sqliteBufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", IDSuffixEscapeChar: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		match               = Match([]string{"title", "body"}, "quick fox")
+	)
+
+	tests := []struct {
+		name    string
+		builder Query
+		result  string
+		args    []any
+		query   rel.Query
+	}{
+		{
+			name:    "postgres",
+			builder: Query{BufferFactory: pgBufferFactory},
+			result:  "SELECT \"posts\".* FROM \"posts\" WHERE to_tsvector('simple', coalesce(\"posts\".\"title\", '') || ' ' || coalesce(\"posts\".\"body\", '')) @@ plainto_tsquery('simple', $1);",
+			args:    []any{"quick fox"},
+			query:   rel.Build("posts", rel.Where(match)),
+		},
+		{
+			name:    "postgres single field with config",
+			builder: Query{BufferFactory: pgBufferFactory, Filter: Filter{FullTextConfig: "english"}},
+			result:  "SELECT \"posts\".* FROM \"posts\" WHERE to_tsvector('english', \"posts\".\"title\") @@ plainto_tsquery('english', $1);",
+			args:    []any{"quick fox"},
+			query:   rel.Build("posts", rel.Where(Match([]string{"title"}, "quick fox"))),
+		},
+		{
+			name:    "postgres rank",
+			builder: Query{BufferFactory: pgBufferFactory},
+			result:  "SELECT \"posts\".*,ts_rank(to_tsvector('simple', \"posts\".\"title\"), plainto_tsquery('simple', $1)) AS \"rank\" FROM \"posts\" WHERE to_tsvector('simple', \"posts\".\"title\") @@ plainto_tsquery('simple', $2) ORDER BY \"rank\" DESC, \"posts\".\"id\" ASC;",
+			args:    []any{"fox", "fox"},
+			query:   rel.Build("posts", SelectRank(Match([]string{"title"}, "fox"), "rank"), rel.Where(Match([]string{"title"}, "fox")), rel.SortDesc("rank"), rel.SortAsc("id")),
+		},
+		{
+			name:    "mysql",
+			builder: Query{BufferFactory: mysqlBufferFactory, Filter: Filter{FullText: FullTextMySQL}},
+			result:  "SELECT `posts`.*,MATCH(`posts`.`title`,`posts`.`body`) AGAINST(? IN NATURAL LANGUAGE MODE) AS `rank` FROM `posts` WHERE MATCH(`posts`.`title`,`posts`.`body`) AGAINST(? IN NATURAL LANGUAGE MODE) ORDER BY `rank` DESC;",
+			args:    []any{"quick fox", "quick fox"},
+			query:   rel.Build("posts", SelectRank(match, "rank"), rel.Where(match), rel.SortDesc("rank")),
+		},
+		{
+			name:    "sqlite virtual table",
+			builder: Query{BufferFactory: sqliteBufferFactory, Filter: Filter{FullText: FullTextSQLite}},
+			result:  "SELECT \"posts\".*,-bm25(\"posts\") AS \"rank\" FROM \"posts\" WHERE \"posts\" MATCH ? ORDER BY \"rank\" DESC;",
+			args:    []any{"{title body} : (\"quick\" \"fox\")"},
+			query:   rel.Build("posts", SelectRank(match, "rank"), rel.Where(match), rel.SortDesc("rank")),
+		},
+		{
+			name:    "sqlite index",
+			builder: Query{BufferFactory: sqliteBufferFactory, Filter: Filter{FullText: FullTextSQLite}},
+			result:  "SELECT \"posts\".*,(SELECT -bm25(\"posts_search\") FROM \"posts_search\" WHERE \"posts_search\" MATCH ? AND \"posts_search\".rowid=\"posts\".rowid) AS \"rank\" FROM \"posts\" WHERE \"posts\".rowid IN (SELECT rowid FROM \"posts_search\" WHERE \"posts_search\" MATCH ?);",
+			args:    []any{"{title} : (\"say\" \"\"\"hi\"\"\")", "{title} : (\"say\" \"\"\"hi\"\"\")"},
+			query: rel.Build("posts",
+				SelectRank(MatchIndex("posts_search", []string{"title"}, `say "hi"`), "rank"),
+				rel.Where(MatchIndex("posts_search", []string{"title"}, `say "hi"`)),
+			),
+		},
+		{
+			name:    "sqlite empty query",
+			builder: Query{BufferFactory: sqliteBufferFactory, Filter: Filter{FullText: FullTextSQLite}},
+			result:  "SELECT \"posts\".*,0 AS \"rank\" FROM \"posts\" WHERE 1=0;",
+			query: rel.Build("posts",
+				SelectRank(MatchIndex("posts_search", []string{"title"}, " \t"), "rank"),
+				rel.Where(MatchIndex("posts_search", []string{"title"}, " \t")),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, args := test.builder.Build(test.query)
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
+func TestSelectRank_invalidFilter(t *testing.T) {
+	var (
+		queryBuilder = Query{BufferFactory: BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`"}}}
+		rank         rel.JoinQuery
+	)
+
+	assert.NotPanics(t, func() {
+		rank = SelectRank(rel.Eq("title", "fox"), "rank")
+	})
+
+	assert.PanicsWithError(t, "invalid argument: SelectRank requires full-text match filter", func() {
+		queryBuilder.Build(rel.Build("posts", rank))
+	})
+}
+
+func TestIndex_Build_fullText(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", IDSuffixEscapeChar: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		create        = rel.Index{Op: rel.SchemaCreate, Table: "posts", Name: "posts_search", Columns: []string{"title", "body"}, Options: string(FullTextIndex)}
+		drop          = rel.Index{Op: rel.SchemaDrop, Table: "posts", Name: "posts_search", Optional: true, Options: string(FullTextIndex)}
+	)
+
+	tests := []struct {
+		result   string
+		fullText FullTextDialect
+		index    rel.Index
+	}{
+		{
+			result: "CREATE INDEX \"posts_search\" ON \"posts\" USING GIN (to_tsvector('simple', coalesce(\"title\", '') || ' ' || coalesce(\"body\", '')));",
+			index:  create,
+		},
+		{
+			result: "DROP INDEX IF EXISTS \"posts_search\";",
+			index:  drop,
+		},
+		{
+			result:   "CREATE FULLTEXT INDEX \"posts_search\" ON \"posts\" (\"title\", \"body\");",
+			fullText: FullTextMySQL,
+			index:    create,
+		},
+		{
+			result: "CREATE VIRTUAL TABLE \"posts_search\" USING fts5(\"title\", \"body\", content='posts'); " +
+				"CREATE TRIGGER \"posts_search_ai\" AFTER INSERT ON \"posts\" BEGIN INSERT INTO \"posts_search\"(rowid, \"title\", \"body\") VALUES (new.rowid, new.\"title\", new.\"body\"); END; " +
+				"CREATE TRIGGER \"posts_search_ad\" AFTER DELETE ON \"posts\" BEGIN INSERT INTO \"posts_search\"(\"posts_search\", rowid, \"title\", \"body\") VALUES ('delete', old.rowid, old.\"title\", old.\"body\"); END; " +
+				"CREATE TRIGGER \"posts_search_au\" AFTER UPDATE ON \"posts\" BEGIN INSERT INTO \"posts_search\"(\"posts_search\", rowid, \"title\", \"body\") VALUES ('delete', old.rowid, old.\"title\", old.\"body\"); INSERT INTO \"posts_search\"(rowid, \"title\", \"body\") VALUES (new.rowid, new.\"title\", new.\"body\"); END; " +
+				"INSERT INTO \"posts_search\"(\"posts_search\") VALUES ('rebuild');",
+			fullText: FullTextSQLite,
+			index:    create,
+		},
+		{
+			result:   "DROP TRIGGER IF EXISTS \"posts_search_ai\"; DROP TRIGGER IF EXISTS \"posts_search_ad\"; DROP TRIGGER IF EXISTS \"posts_search_au\"; DROP TABLE IF EXISTS \"posts_search\";",
+			fullText: FullTextSQLite,
+			index:    drop,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			indexBuilder := Index{BufferFactory: bufferFactory, Filter: Filter{FullText: test.fullText}}
+			assert.Equal(t, test.result, indexBuilder.Build(test.index))
+		})
+	}
+}
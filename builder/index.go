@@ -19,6 +19,13 @@ type Index struct {
 func (i Index) Build(index rel.Index) string {
 	buffer := i.BufferFactory.Create()
 
+	if index.Options == string(FullTextIndex) {
+		i.WriteFullTextIndex(&buffer, index)
+		buffer.WriteByte(';')
+
+		return buffer.String()
+	}
+
 	switch index.Op {
 	case rel.SchemaCreate:
 		i.WriteCreateIndex(&buffer, index)
@@ -48,14 +55,7 @@ func (i Index) WriteCreateIndex(buffer *Buffer, index rel.Index) {
 	buffer.WriteString(" ON ")
 	buffer.WriteTable(index.Table)
 
-	buffer.WriteString(" (")
-	for n, col := range index.Columns {
-		if n > 0 {
-			buffer.WriteString(", ")
-		}
-		buffer.WriteEscape(col)
-	}
-	buffer.WriteString(")")
+	i.writeColumns(buffer, index.Columns)
 	if !index.Filter.None() {
 		if !i.SupportFilter {
 			log.Print("[REL] Adapter does not support filtered/partial indexes")
@@ -66,6 +66,147 @@ func (i Index) WriteCreateIndex(buffer *Buffer, index rel.Index) {
 	}
 }
 
+// WriteFullTextIndex writes creation or deletion of full-text index to buffer.
+//
+// SQLite's full-text index is an external content FTS5 virtual table named after the index,
+// it's kept in sync with the table by insert, update and delete triggers named after the index with _ai, _au and _ad suffix,
+// and populated from the existing rows when it's created. The statements are separated by semicolon.
+func (i Index) WriteFullTextIndex(buffer *Buffer, index rel.Index) {
+	switch i.Filter.FullText {
+	case FullTextMySQL:
+		if index.Op == rel.SchemaDrop {
+			i.WriteDropIndex(buffer, index)
+			return
+		}
+
+		buffer.WriteString("CREATE FULLTEXT INDEX ")
+		buffer.WriteEscape(index.Name)
+		buffer.WriteString(" ON ")
+		buffer.WriteTable(index.Table)
+		i.writeColumns(buffer, index.Columns)
+	case FullTextSQLite:
+		if index.Op == rel.SchemaDrop {
+			i.writeDropFTS5(buffer, index)
+		} else {
+			i.writeCreateFTS5(buffer, index)
+		}
+	default:
+		if index.Op == rel.SchemaDrop {
+			i.WriteDropIndex(buffer, index)
+			return
+		}
+
+		buffer.WriteString("CREATE INDEX ")
+		if index.Optional {
+			buffer.WriteString("IF NOT EXISTS ")
+		}
+		buffer.WriteEscape(index.Name)
+		buffer.WriteString(" ON ")
+		buffer.WriteTable(index.Table)
+		buffer.WriteString(" USING GIN (")
+		i.Filter.WriteTSVector(buffer, "", index.Columns)
+		buffer.WriteByte(')')
+	}
+}
+
+func (i Index) writeCreateFTS5(buffer *Buffer, index rel.Index) {
+	buffer.WriteString("CREATE VIRTUAL TABLE ")
+	if index.Optional {
+		buffer.WriteString("IF NOT EXISTS ")
+	}
+	buffer.WriteTable(index.Name)
+	buffer.WriteString(" USING fts5(")
+	for _, col := range index.Columns {
+		buffer.WriteEscape(col)
+		buffer.WriteString(", ")
+	}
+	buffer.WriteString("content=")
+	buffer.WriteString(buffer.Quoter.Value(index.Table))
+	buffer.WriteString("); ")
+
+	for _, trigger := range []struct {
+		suffix string
+		event  string
+		delete bool
+		insert bool
+	}{
+		{suffix: "_ai", event: "INSERT", insert: true},
+		{suffix: "_ad", event: "DELETE", delete: true},
+		{suffix: "_au", event: "UPDATE", delete: true, insert: true},
+	} {
+		buffer.WriteString("CREATE TRIGGER ")
+		if index.Optional {
+			buffer.WriteString("IF NOT EXISTS ")
+		}
+		buffer.WriteEscape(index.Name + trigger.suffix)
+		buffer.WriteString(" AFTER ")
+		buffer.WriteString(trigger.event)
+		buffer.WriteString(" ON ")
+		buffer.WriteTable(index.Table)
+		buffer.WriteString(" BEGIN")
+		if trigger.delete {
+			i.writeFTS5Row(buffer, index, "old", true)
+		}
+		if trigger.insert {
+			i.writeFTS5Row(buffer, index, "new", false)
+		}
+		buffer.WriteString(" END; ")
+	}
+
+	// populates the index from existing rows.
+	buffer.WriteString("INSERT INTO ")
+	buffer.WriteTable(index.Name)
+	buffer.WriteByte('(')
+	buffer.WriteEscape(index.Name)
+	buffer.WriteString(") VALUES ('rebuild')")
+}
+
+// writeFTS5Row writes statement inside trigger that adds row to FTS5 index, or removes it when delete is true.
+func (i Index) writeFTS5Row(buffer *Buffer, index rel.Index, row string, delete bool) {
+	buffer.WriteString(" INSERT INTO ")
+	buffer.WriteTable(index.Name)
+	buffer.WriteByte('(')
+	if delete {
+		buffer.WriteEscape(index.Name)
+		buffer.WriteString(", ")
+	}
+	buffer.WriteString("rowid")
+	for _, col := range index.Columns {
+		buffer.WriteString(", ")
+		buffer.WriteEscape(col)
+	}
+	buffer.WriteString(") VALUES (")
+	if delete {
+		buffer.WriteString("'delete', ")
+	}
+	buffer.WriteString(row)
+	buffer.WriteString(".rowid")
+	for _, col := range index.Columns {
+		buffer.WriteString(", ")
+		buffer.WriteString(row)
+		buffer.WriteByte('.')
+		buffer.WriteEscape(col)
+	}
+	buffer.WriteString(");")
+}
+
+func (i Index) writeDropFTS5(buffer *Buffer, index rel.Index) {
+	for _, suffix := range []string{"_ai", "_ad", "_au"} {
+		buffer.WriteString("DROP TRIGGER ")
+		if index.Optional {
+			buffer.WriteString("IF EXISTS ")
+		}
+		buffer.WriteEscape(index.Name + suffix)
+		buffer.WriteString("; ")
+	}
+
+	buffer.WriteString("DROP TABLE ")
+	if index.Optional {
+		buffer.WriteString("IF EXISTS ")
+	}
+	buffer.WriteTable(index.Name)
+}
+
 // WriteDropIndex to buffer
 func (i Index) WriteDropIndex(buffer *Buffer, index rel.Index) {
 	buffer.WriteString("DROP INDEX ")
@@ -82,6 +223,17 @@ func (i Index) WriteDropIndex(buffer *Buffer, index rel.Index) {
 	}
 }
 
+func (i Index) writeColumns(buffer *Buffer, columns []string) {
+	buffer.WriteString(" (")
+	for n, col := range columns {
+		if n > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteEscape(col)
+	}
+	buffer.WriteString(")")
+}
+
 // WriteOptions sql to buffer.
 func (i Index) WriteOptions(buffer *Buffer, options string) {
 	if options == "" {
@@ -9,7 +9,9 @@ type Insert struct {
 	BufferFactory         BufferFactory
 	ReturningPrimaryValue bool
 	InsertDefaultValues   bool
+	UnorderedFields       bool
 	OnConflict            OnConflict
+	Returning             Returning
 }
 
 // Build sql query and its arguments.
@@ -26,15 +28,35 @@ func (i Insert) Build(table string, primaryField string, mutates map[string]rel.
 	return buffer.String(), buffer.Arguments()
 }
 
+// BuildReturning builds sql query that returns columns of the inserted row, and its arguments.
+// It returns false when returning is not supported, in which case the query doesn't return any row.
+func (i Insert) BuildReturning(table string, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict, returning []string) (string, []any, bool) {
+	buffer := i.BufferFactory.Create()
+
+	i.WriteInsertInto(&buffer, table)
+	i.writeValues(&buffer, mutates, returning)
+	i.OnConflict.WriteMutates(&buffer, mutates, onConflict)
+	i.Returning.WriteClause(&buffer, returning)
+
+	buffer.WriteString(";")
+
+	return buffer.String(), buffer.Arguments(), i.Returning.Supported()
+}
+
 func (i Insert) WriteInsertInto(buffer *Buffer, table string) {
 	buffer.WriteString("INSERT INTO ")
 	buffer.WriteTable(table)
 }
 
 func (i Insert) WriteValues(buffer *Buffer, mutates map[string]rel.Mutate) {
+	i.writeValues(buffer, mutates, nil)
+}
+
+func (i Insert) writeValues(buffer *Buffer, mutates map[string]rel.Mutate, returning []string) {
 	count := len(mutates)
 
 	if count == 0 && i.InsertDefaultValues {
+		i.Returning.WriteOutput(buffer, "INSERTED", returning)
 		buffer.WriteString(" DEFAULT VALUES")
 	} else {
 		buffer.WriteString(" (")
@@ -44,8 +66,8 @@ func (i Insert) WriteValues(buffer *Buffer, mutates map[string]rel.Mutate) {
 			arguments = make([]any, 0, count)
 		)
 
-		for field, mut := range mutates {
-			if mut.Type == rel.ChangeSetOp {
+		for _, field := range mutatesFields(mutates, i.UnorderedFields) {
+			if mut := mutates[field]; mut.Type == rel.ChangeSetOp {
 				if n > 0 {
 					buffer.WriteByte(',')
 				}
@@ -56,7 +78,9 @@ func (i Insert) WriteValues(buffer *Buffer, mutates map[string]rel.Mutate) {
 			}
 		}
 
-		buffer.WriteString(") VALUES (")
+		buffer.WriteByte(')')
+		i.Returning.WriteOutput(buffer, "INSERTED", returning)
+		buffer.WriteString(" VALUES (")
 
 		for i := range arguments {
 			if i > 0 {
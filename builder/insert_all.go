@@ -9,6 +9,7 @@ type InsertAll struct {
 	BufferFactory         BufferFactory
 	ReturningPrimaryValue bool
 	OnConflict            OnConflict
+	Returning             Returning
 }
 
 // Build SQL string and its arguments.
@@ -24,12 +25,30 @@ func (ia InsertAll) Build(table string, primaryField string, fields []string, bu
 	return buffer.String(), buffer.Arguments()
 }
 
+// BuildReturning builds SQL string that returns columns of the inserted rows, and its arguments.
+// It returns false when returning is not supported, in which case the query doesn't return any row.
+func (ia InsertAll) BuildReturning(table string, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict, returning []string) (string, []any, bool) {
+	buffer := ia.BufferFactory.Create()
+
+	ia.WriteInsertInto(&buffer, table)
+	ia.writeValues(&buffer, fields, bulkMutates, returning)
+	ia.OnConflict.Write(&buffer, fields, onConflict)
+	ia.Returning.WriteClause(&buffer, returning)
+	buffer.WriteString(";")
+
+	return buffer.String(), buffer.Arguments(), ia.Returning.Supported()
+}
+
 func (ia InsertAll) WriteInsertInto(buffer *Buffer, table string) {
 	buffer.WriteString("INSERT INTO ")
 	buffer.WriteTable(table)
 }
 
 func (ia InsertAll) WriteValues(buffer *Buffer, fields []string, bulkMutates []map[string]rel.Mutate) {
+	ia.writeValues(buffer, fields, bulkMutates, nil)
+}
+
+func (ia InsertAll) writeValues(buffer *Buffer, fields []string, bulkMutates []map[string]rel.Mutate, returning []string) {
 	var (
 		fieldsCount  = len(fields)
 		mutatesCount = len(bulkMutates)
@@ -45,7 +64,9 @@ func (ia InsertAll) WriteValues(buffer *Buffer, fields []string, bulkMutates []m
 		}
 	}
 
-	buffer.WriteString(") VALUES ")
+	buffer.WriteByte(')')
+	ia.Returning.WriteOutput(buffer, "INSERTED", returning)
+	buffer.WriteString(" VALUES ")
 
 	for i, mutates := range bulkMutates {
 		buffer.WriteByte('(')
@@ -0,0 +1,202 @@
+package builder
+
+import (
+	"errors"
+
+	"github.com/go-rel/rel"
+	"github.com/jinzhu/inflection"
+)
+
+// ErrUnresolvableJoin is panicked by Query builder when join condition can't be resolved, wrapped in sql.BuildError.
+var ErrUnresolvableJoin = errors.New("unable to resolve join condition")
+
+// JoinResolver resolves join condition of join query without explicit From and To.
+//
+// from is a field of table and to is a field of joinTable, both without table qualifier.
+type JoinResolver interface {
+	ResolveJoin(table, joinTable string) (from, to string, ok bool)
+}
+
+// JoinResolvers tries each resolver in order.
+type JoinResolvers []JoinResolver
+
+// ResolveJoin using the first resolver that is able to resolve.
+func (jr JoinResolvers) ResolveJoin(table, joinTable string) (string, string, bool) {
+	for _, resolver := range jr {
+		if from, to, ok := resolver.ResolveJoin(table, joinTable); ok {
+			return from, to, true
+		}
+	}
+
+	return "", "", false
+}
+
+// JoinNaming resolves join condition using naming convention: table.<singular joinTable>_id = joinTable.id.
+type JoinNaming struct{}
+
+// ResolveJoin using naming convention.
+func (JoinNaming) ResolveJoin(table, joinTable string) (string, string, bool) {
+	return inflection.Singular(joinTable) + "_id", "id", true
+}
+
+type joinCondition struct {
+	from      string
+	to        string
+	ambiguous bool
+}
+
+// Associations resolves join condition using association of registered entities.
+type Associations struct {
+	conditions map[[2]string]joinCondition
+}
+
+// NewAssociations registers belongs to, has one and has many association of given entities.
+// The opposite direction of each association is registered as well, unless it's defined by the other entity.
+func NewAssociations(entities ...any) Associations {
+	var (
+		associations = Associations{conditions: make(map[[2]string]joinCondition)}
+		reverses     = make(map[[2]string]joinCondition)
+	)
+
+	for _, entity := range entities {
+		meta := rel.NewDocument(entity, true).Meta()
+
+		for _, names := range [][]string{meta.BelongsTo(), meta.HasOne(), meta.HasMany()} {
+			for _, name := range names {
+				assoc := meta.Association(name)
+				if assoc.Through() != "" {
+					continue
+				}
+
+				var (
+					table     = meta.Table()
+					joinTable = assoc.DocumentMeta().Table()
+				)
+
+				register(associations.conditions, [2]string{table, joinTable}, assoc.ReferenceField(), assoc.ForeignField())
+				register(reverses, [2]string{joinTable, table}, assoc.ForeignField(), assoc.ReferenceField())
+			}
+		}
+	}
+
+	for key, condition := range reverses {
+		if _, ok := associations.conditions[key]; !ok {
+			associations.conditions[key] = condition
+		}
+	}
+
+	return associations
+}
+
+func register(conditions map[[2]string]joinCondition, key [2]string, from, to string) {
+	if existing, ok := conditions[key]; ok {
+		// multiple associations between the same tables can't be resolved by table name.
+		if existing.from != from || existing.to != to {
+			existing.ambiguous = true
+			conditions[key] = existing
+		}
+		return
+	}
+
+	conditions[key] = joinCondition{from: from, to: to}
+}
+
+// ResolveJoin using registered association.
+func (a Associations) ResolveJoin(table, joinTable string) (string, string, bool) {
+	condition, ok := a.conditions[[2]string{table, joinTable}]
+	if !ok || condition.ambiguous {
+		return "", "", false
+	}
+
+	return condition.from, condition.to, true
+}
+
+func (q Query) resolveJoin(table, joinTable string) (string, string) {
+	var (
+		resolver JoinResolver = JoinNaming{}
+	)
+
+	if q.JoinResolver != nil {
+		resolver = q.JoinResolver
+	}
+
+	from, to, ok := resolver.ResolveJoin(table, joinTable)
+	if !ok {
+		fail("%w from %s to %s, use explicit join condition", ErrUnresolvableJoin, table, joinTable)
+	}
+
+	return from, to
+}
+
+// UsingJoin joins table using columns that have the same name in both tables.
+type UsingJoin struct {
+	Mode    string
+	Table   string
+	Columns []string
+}
+
+// JoinUsing joins table using given columns.
+func JoinUsing(table string, columns ...string) rel.JoinQuery {
+	return JoinUsingWith("JOIN", table, columns...)
+}
+
+// JoinUsingWith joins table with given mode using given columns.
+func JoinUsingWith(mode string, table string, columns ...string) rel.JoinQuery {
+	return rel.JoinQuery{
+		Mode:      mode,
+		Table:     table,
+		Arguments: []any{UsingJoin{Mode: mode, Table: table, Columns: columns}},
+	}
+}
+
+// SubQueryJoin joins the result of a query as alias.
+//
+// Lateral sub query is able to reference columns of preceding tables.
+// Filter is written as ON condition, which can be omitted for CROSS JOIN.
+type SubQueryJoin struct {
+	Mode    string
+	Query   rel.Query
+	Alias   string
+	Lateral bool
+	Filter  rel.FilterQuery
+}
+
+// JoinSubQuery joins the result of query as alias, using given filter as join condition.
+func JoinSubQuery(query rel.Query, alias string, filter ...rel.FilterQuery) rel.JoinQuery {
+	return SubQueryJoin{Mode: "JOIN", Query: query, Alias: alias, Filter: rel.And(filter...)}.joinQuery()
+}
+
+// JoinLateral joins the result of lateral query as alias, using given filter as join condition.
+func JoinLateral(query rel.Query, alias string, filter ...rel.FilterQuery) rel.JoinQuery {
+	return JoinLateralWith("JOIN", query, alias, filter...)
+}
+
+// JoinLateralWith joins the result of lateral query with given mode.
+func JoinLateralWith(mode string, query rel.Query, alias string, filter ...rel.FilterQuery) rel.JoinQuery {
+	return SubQueryJoin{Mode: mode, Query: query, Alias: alias, Lateral: true, Filter: rel.And(filter...)}.joinQuery()
+}
+
+func (sj SubQueryJoin) joinQuery() rel.JoinQuery {
+	return rel.JoinQuery{
+		Mode:      sj.Mode,
+		Table:     sj.Alias,
+		Arguments: []any{sj},
+	}
+}
+
+// joinClause returns join defined by this package that is carried by join query, nil is returned for regular join.
+// It's carried the same way as query parts returned by queryClause, since rel.JoinQuery only supports ON condition.
+func joinClause(join rel.JoinQuery) any {
+	if len(join.Arguments) != 1 {
+		return nil
+	}
+
+	switch v := join.Arguments[0].(type) {
+	case UsingJoin:
+		return v
+	case SubQueryJoin:
+		return v
+	}
+
+	return nil
+}
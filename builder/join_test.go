@@ -0,0 +1,172 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+type joinUser struct {
+	ID        int
+	RoleID    int
+	Role      joinRole      `ref:"role_id" fk:"id"`
+	Addresses []joinAddress `ref:"id" fk:"owner_id"`
+}
+
+func (joinUser) Table() string { return "users" }
+
+type joinRole struct {
+	ID int
+}
+
+func (joinRole) Table() string { return "roles" }
+
+type joinAddress struct {
+	ID       int
+	OwnerID  int
+	Owner    joinUser `ref:"owner_id" fk:"id"`
+	EditorID int
+	Editor   joinUser `ref:"editor_id" fk:"id"`
+}
+
+func (joinAddress) Table() string { return "addresses" }
+
+func TestJoinNaming_ResolveJoin(t *testing.T) {
+	from, to, ok := JoinNaming{}.ResolveJoin("products", "categories")
+	assert.True(t, ok)
+	assert.Equal(t, "category_id", from)
+	assert.Equal(t, "id", to)
+}
+
+func TestAssociations_ResolveJoin(t *testing.T) {
+	var (
+		associations = NewAssociations(joinUser{}, joinAddress{})
+	)
+
+	tests := []struct {
+		table     string
+		joinTable string
+		from      string
+		to        string
+		ok        bool
+	}{
+		{table: "users", joinTable: "roles", from: "role_id", to: "id", ok: true},
+		{table: "roles", joinTable: "users", from: "id", to: "role_id", ok: true},
+		{table: "users", joinTable: "addresses", from: "id", to: "owner_id", ok: true},
+		{table: "addresses", joinTable: "users", ok: false},
+		{table: "users", joinTable: "tags", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.table+" "+test.joinTable, func(t *testing.T) {
+			from, to, ok := associations.ResolveJoin(test.table, test.joinTable)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.from, from)
+			assert.Equal(t, test.to, to)
+		})
+	}
+}
+
+func TestQuery_WriteJoin_resolver(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, JoinResolver: JoinResolvers{NewAssociations(joinUser{}), JoinNaming{}}}
+	)
+
+	tests := []struct {
+		result string
+		table  string
+		query  rel.Query
+	}{
+		{
+			result: " JOIN `roles` ON `users`.`role_id`=`roles`.`id`",
+			table:  "users",
+			query:  rel.Join("roles"),
+		},
+		{
+			result: " JOIN `addresses` AS `a` ON `u`.`id`=`a`.`owner_id`",
+			table:  "users as u",
+			query:  rel.Join("addresses as a"),
+		},
+		{
+			result: " JOIN `categories` ON `products`.`category_id`=`categories`.`id`",
+			table:  "products",
+			query:  rel.Join("categories"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			buffer := bufferFactory.Create()
+			queryBuilder.WriteJoin(&buffer, test.table, test.query.JoinQuery)
+			assert.Equal(t, test.result, buffer.String())
+		})
+	}
+}
+
+func TestQuery_WriteJoin_unresolvable(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, JoinResolver: NewAssociations(joinUser{})}
+	)
+
+	assert.PanicsWithError(t, "unable to resolve join condition from users to tags, use explicit join condition", func() {
+		queryBuilder.Build(rel.From("users").Join("tags"))
+	})
+}
+
+func TestQuery_Build_joinClause(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, Filter: Filter{}}
+		latest        = rel.From("orders").Where(where.Fragment("\"orders\".\"user_id\"=\"users\".\"id\""), rel.Gt("orders.total", 100)).SortDesc("created_at").Limit(3)
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		query  rel.Query
+	}{
+		{
+			result: "SELECT \"order_items\".* FROM \"order_items\" JOIN \"order_discounts\" USING (\"order_id\",\"line_no\");",
+			query:  rel.Build("order_items", JoinUsing("order_discounts", "order_id", "line_no")),
+		},
+		{
+			result: "SELECT \"order_items\".* FROM \"order_items\" LEFT JOIN \"order_discounts\" AS \"d\" USING (\"order_id\");",
+			query:  rel.Build("order_items", JoinUsingWith("LEFT JOIN", "order_discounts as d", "order_id")),
+		},
+		{
+			result: "SELECT \"users\".\"id\",\"o\".\"total\" FROM \"users\" JOIN LATERAL (SELECT \"orders\".* FROM \"orders\" WHERE (\"orders\".\"user_id\"=\"users\".\"id\" AND \"orders\".\"total\">$1) ORDER BY \"orders\".\"created_at\" DESC LIMIT 3) AS \"o\" ON 1=1 WHERE \"users\".\"active\"=$2;",
+			args:   []any{100, true},
+			query:  rel.Build("users", rel.Select("id", "o.total"), JoinLateral(latest, "o"), rel.Where(rel.Eq("active", true))),
+		},
+		{
+			result: "SELECT \"users\".* FROM \"users\" LEFT JOIN LATERAL (SELECT \"orders\".* FROM \"orders\" WHERE (\"orders\".\"user_id\"=\"users\".\"id\" AND \"orders\".\"total\">$1) ORDER BY \"orders\".\"created_at\" DESC LIMIT 3) AS \"o\" ON \"o\".\"status\"=$2;",
+			args:   []any{100, "paid"},
+			query:  rel.Build("users", JoinLateralWith("LEFT JOIN", latest, "o", rel.Eq("status", "paid"))),
+		},
+		{
+			result: "SELECT \"users\".* FROM \"users\" CROSS JOIN LATERAL (SELECT \"orders\".* FROM \"orders\" WHERE (\"orders\".\"user_id\"=\"users\".\"id\" AND \"orders\".\"total\">$1) ORDER BY \"orders\".\"created_at\" DESC LIMIT 3) AS \"o\";",
+			args:   []any{100},
+			query:  rel.Build("users", JoinLateralWith("CROSS JOIN", latest, "o")),
+		},
+		{
+			result: "SELECT \"users\".* FROM \"users\" JOIN (SELECT \"orders\".\"user_id\",COUNT(\"orders\".\"id\") AS \"count\" FROM \"orders\" WHERE \"orders\".\"status\"=$1 GROUP BY \"orders\".\"user_id\") AS \"c\" ON \"c\".\"user_id\"=\"users\".\"id\" WHERE \"users\".\"id\">$2;",
+			args:   []any{"paid", 10},
+			query: rel.Build("users",
+				JoinSubQuery(rel.Select("user_id", "COUNT(id) AS count").From("orders").Where(rel.Eq("status", "paid")).Group("user_id"), "c", where.Fragment("\"c\".\"user_id\"=\"users\".\"id\"")),
+				rel.Where(rel.Gt("id", 10)),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result, args := queryBuilder.Build(test.query)
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
@@ -0,0 +1,390 @@
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-rel/rel"
+)
+
+// ErrUnsupported is panicked by builder when an operation is not supported by the dialect, wrapped in sql.BuildError.
+var ErrUnsupported = errors.New("operation is not supported by the dialect")
+
+// JSONDialect defines how JSON operators are written.
+type JSONDialect int
+
+const (
+	// JSONPostgreSQL writes jsonb operators and functions such as #>>, @> and jsonb_set.
+	JSONPostgreSQL JSONDialect = iota
+	// JSONMySQL writes JSON_EXTRACT, JSON_CONTAINS and JSON_SET.
+	JSONMySQL
+	// JSONSQLite writes json_extract and json_set, containment is not supported.
+	JSONSQLite
+)
+
+// JSONPath references a value inside JSON document stored in field.
+// Each element of path is an object key, or an array index when it's a number.
+type JSONPath struct {
+	Field string
+	Path  []string
+}
+
+// JSONField references a value inside JSON document stored in field.
+func JSONField(field string, path ...string) JSONPath {
+	return JSONPath{Field: field, Path: path}
+}
+
+func (jp JSONPath) String() string {
+	return jp.Field + "." + strings.Join(jp.Path, ".")
+}
+
+// JSONFilter compares the value extracted from JSON document as text.
+type JSONFilter struct {
+	Path  JSONPath
+	Op    rel.FilterOp
+	Value any
+}
+
+// JSONContainsFilter filters JSON document that contains value.
+type JSONContainsFilter struct {
+	Field string
+	Value any
+}
+
+// JSONHasPathFilter filters JSON document that has value at path.
+type JSONHasPathFilter struct {
+	Path JSONPath
+}
+
+// JSONCompare compares the value extracted from JSON document as text using comparison op.
+func JSONCompare(path JSONPath, op rel.FilterOp, value any) rel.FilterQuery {
+	return jsonFilterQuery(path.String(), JSONFilter{Path: path, Op: op, Value: value})
+}
+
+// JSONEq filters JSON document which value at path is equal to value.
+func JSONEq(path JSONPath, value any) rel.FilterQuery {
+	return JSONCompare(path, rel.FilterEqOp, value)
+}
+
+// JSONNe filters JSON document which value at path is not equal to value.
+func JSONNe(path JSONPath, value any) rel.FilterQuery {
+	return JSONCompare(path, rel.FilterNeOp, value)
+}
+
+// JSONContains filters JSON document in field that contains value, value is encoded as JSON.
+func JSONContains(field string, value any) rel.FilterQuery {
+	return jsonFilterQuery(field, JSONContainsFilter{Field: field, Value: value})
+}
+
+// JSONHasPath filters JSON document that has value at path.
+func JSONHasPath(path JSONPath) rel.FilterQuery {
+	return jsonFilterQuery(path.String(), JSONHasPathFilter{Path: path})
+}
+
+func jsonFilterQuery(field string, filter any) rel.FilterQuery {
+	return rel.FilterQuery{
+		Type:  rel.FilterFragmentOp,
+		Field: field,
+		Value: []any{filter},
+	}
+}
+
+// JSONExtractField is a select field that is extracted from JSON document as text.
+type JSONExtractField struct {
+	Path  JSONPath
+	Alias string
+}
+
+// SelectJSON selects the value extracted from JSON document as alias.
+func SelectJSON(path JSONPath, alias string) rel.JoinQuery {
+	return rel.JoinQuery{
+		Mode:      "SELECT",
+		Table:     alias,
+		Arguments: []any{JSONExtractField{Path: path, Alias: alias}},
+	}
+}
+
+// jsonMutationSeq numbers JSON mutations in the order they're created.
+var jsonMutationSeq uint64
+
+// JSONMutation sets or removes value inside JSON document.
+//
+// Multiple mutations of the same field are combined into a single assignment, applied in the order they're created.
+type JSONMutation struct {
+	Path   JSONPath
+	Value  any
+	Remove bool
+	seq    uint64
+}
+
+// mutate returns fragment mutation that carries JSON mutation.
+// The mutation is keyed by its sequence, so that it doesn't replace other mutation of the same path.
+func (jm JSONMutation) mutate() rel.Mutate {
+	jm.seq = atomic.AddUint64(&jsonMutationSeq, 1)
+
+	op := " SET #"
+	if jm.Remove {
+		op = " REMOVE #"
+	}
+
+	return rel.Mutate{
+		Type:  rel.ChangeFragmentOp,
+		Field: jm.Path.String() + op + strconv.FormatUint(jm.seq, 10),
+		Value: []any{jm},
+	}
+}
+
+// JSONSet sets value at path inside JSON document, value is encoded as JSON.
+func JSONSet(path JSONPath, value any) rel.Mutate {
+	return JSONMutation{Path: path, Value: value}.mutate()
+}
+
+// JSONRemove removes value at path from JSON document.
+func JSONRemove(path JSONPath) rel.Mutate {
+	return JSONMutation{Path: path, Remove: true}.mutate()
+}
+
+// jsonMutation returns JSON mutation carried by fragment mutation.
+func jsonMutation(mut rel.Mutate) (JSONMutation, bool) {
+	if values, ok := mut.Value.([]any); ok && len(values) == 1 {
+		jm, ok := values[0].(JSONMutation)
+		return jm, ok
+	}
+
+	return JSONMutation{}, false
+}
+
+// WriteJSONExtract writes the value extracted from JSON document as text to buffer.
+func (f Filter) WriteJSONExtract(buffer *Buffer, table string, path JSONPath) {
+	switch f.JSON {
+	case JSONMySQL:
+		buffer.WriteString("JSON_UNQUOTE(JSON_EXTRACT(")
+		buffer.WriteField(table, path.Field)
+		buffer.WriteString(", ")
+		writeJSONPath(buffer, path.Path)
+		buffer.WriteString("))")
+	case JSONSQLite:
+		buffer.WriteString("json_extract(")
+		buffer.WriteField(table, path.Field)
+		buffer.WriteString(", ")
+		writeJSONPath(buffer, path.Path)
+		buffer.WriteByte(')')
+	default:
+		buffer.WriteField(table, path.Field)
+		buffer.WriteString("#>>")
+		writePostgresPath(buffer, path.Path)
+	}
+}
+
+// WriteJSON writes JSON filter to buffer.
+func (f Filter) WriteJSON(buffer *Buffer, table string, filter any) {
+	switch v := filter.(type) {
+	case JSONFilter:
+		f.WriteJSONExtract(buffer, table, v.Path)
+		buffer.WriteString(comparisonOperator(v.Op))
+		f.writeOperand(buffer, table, v.Value)
+	case JSONContainsFilter:
+		switch f.JSON {
+		case JSONMySQL:
+			buffer.WriteString("JSON_CONTAINS(")
+			buffer.WriteField(table, v.Field)
+			buffer.WriteString(", ")
+			buffer.WriteValue(encodeJSON(v.Value))
+			buffer.WriteByte(')')
+		case JSONSQLite:
+			fail("%w: JSON containment", ErrUnsupported)
+		default:
+			buffer.WriteField(table, v.Field)
+			buffer.WriteString("@>CAST(")
+			buffer.WriteValue(encodeJSON(v.Value))
+			buffer.WriteString(" AS jsonb)")
+		}
+	case JSONHasPathFilter:
+		switch f.JSON {
+		case JSONMySQL:
+			buffer.WriteString("JSON_CONTAINS_PATH(")
+			buffer.WriteField(table, v.Path.Field)
+			buffer.WriteString(", 'one', ")
+			writeJSONPath(buffer, v.Path.Path)
+			buffer.WriteByte(')')
+		case JSONSQLite:
+			buffer.WriteString("json_type(")
+			buffer.WriteField(table, v.Path.Field)
+			buffer.WriteString(", ")
+			writeJSONPath(buffer, v.Path.Path)
+			buffer.WriteString(") IS NOT NULL")
+		default:
+			buffer.WriteByte('(')
+			buffer.WriteField(table, v.Path.Field)
+			buffer.WriteString("#>")
+			writePostgresPath(buffer, v.Path.Path)
+			buffer.WriteString(") IS NOT NULL")
+		}
+	}
+}
+
+// WriteJSONMutations writes assignment of field that combines all JSON mutations to buffer.
+func (f Filter) WriteJSONMutations(buffer *Buffer, field string, mutations []JSONMutation) {
+	buffer.WriteEscape(field)
+	buffer.WriteByte('=')
+	f.writeJSONMutations(buffer, field, mutations)
+}
+
+func (f Filter) writeJSONMutations(buffer *Buffer, field string, mutations []JSONMutation) {
+	if len(mutations) == 0 {
+		buffer.WriteEscape(field)
+		return
+	}
+
+	var (
+		last  = mutations[len(mutations)-1]
+		inner = mutations[:len(mutations)-1]
+	)
+
+	switch f.JSON {
+	case JSONMySQL:
+		if last.Remove {
+			buffer.WriteString("JSON_REMOVE(")
+		} else {
+			buffer.WriteString("JSON_SET(")
+		}
+		f.writeJSONMutations(buffer, field, inner)
+		buffer.WriteString(", ")
+		writeJSONPath(buffer, last.Path.Path)
+		if !last.Remove {
+			buffer.WriteString(", CAST(")
+			buffer.WriteValue(encodeJSON(last.Value))
+			buffer.WriteString(" AS JSON)")
+		}
+		buffer.WriteByte(')')
+	case JSONSQLite:
+		if last.Remove {
+			buffer.WriteString("json_remove(")
+		} else {
+			buffer.WriteString("json_set(")
+		}
+		f.writeJSONMutations(buffer, field, inner)
+		buffer.WriteString(", ")
+		writeJSONPath(buffer, last.Path.Path)
+		if !last.Remove {
+			buffer.WriteString(", json(")
+			buffer.WriteValue(encodeJSON(last.Value))
+			buffer.WriteByte(')')
+		}
+		buffer.WriteByte(')')
+	default:
+		if last.Remove {
+			buffer.WriteByte('(')
+			f.writeJSONMutations(buffer, field, inner)
+			buffer.WriteString("#-")
+			writePostgresPath(buffer, last.Path.Path)
+			buffer.WriteByte(')')
+		} else {
+			buffer.WriteString("jsonb_set(")
+			f.writeJSONMutations(buffer, field, inner)
+			buffer.WriteString(", ")
+			writePostgresPath(buffer, last.Path.Path)
+			buffer.WriteString(", CAST(")
+			buffer.WriteValue(encodeJSON(last.Value))
+			buffer.WriteString(" AS jsonb))")
+		}
+	}
+}
+
+// groupJSONMutations returns JSON mutations of each field, ordered by creation.
+func groupJSONMutations(mutates map[string]rel.Mutate) map[string][]JSONMutation {
+	var (
+		groups map[string][]JSONMutation
+	)
+
+	for _, mut := range mutates {
+		if jm, ok := jsonMutation(mut); ok {
+			if groups == nil {
+				groups = make(map[string][]JSONMutation)
+			}
+
+			groups[jm.Path.Field] = append(groups[jm.Path.Field], jm)
+		}
+	}
+
+	for _, mutations := range groups {
+		sort.Slice(mutations, func(i, j int) bool {
+			return mutations[i].seq < mutations[j].seq
+		})
+	}
+
+	return groups
+}
+
+// writePostgresPath writes path as text array literal, such as '{"a","0"}'.
+func writePostgresPath(buffer *Buffer, path []string) {
+	var (
+		builder strings.Builder
+	)
+
+	builder.WriteByte('{')
+	for i, key := range path {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteByte('"')
+		builder.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(key))
+		builder.WriteByte('"')
+	}
+	builder.WriteByte('}')
+
+	buffer.WriteString(buffer.Quoter.Value(builder.String()))
+}
+
+// writeJSONPath writes path as SQL/JSON path literal, such as '$.a[0]'.
+func writeJSONPath(buffer *Buffer, path []string) {
+	var (
+		builder strings.Builder
+	)
+
+	builder.WriteByte('$')
+	for _, key := range path {
+		if _, err := strconv.Atoi(key); err == nil {
+			builder.WriteByte('[')
+			builder.WriteString(key)
+			builder.WriteByte(']')
+		} else if isJSONIdentifier(key) {
+			builder.WriteByte('.')
+			builder.WriteString(key)
+		} else {
+			builder.WriteString(`."`)
+			builder.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(key))
+			builder.WriteByte('"')
+		}
+	}
+
+	buffer.WriteString(buffer.Quoter.Value(builder.String()))
+}
+
+func isJSONIdentifier(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func encodeJSON(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		fail("%w: unable to encode JSON value: %w", ErrInvalidArgument, err)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,178 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_WriteJSON(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory}
+		path          = JSONField("data", "address", "city")
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		filter Filter
+		query  rel.FilterQuery
+	}{
+		{
+			result: "`users`.`data`#>>'{\"address\",\"city\"}'=?",
+			args:   []any{"Jakarta"},
+			query:  JSONEq(path, "Jakarta"),
+		},
+		{
+			result: "JSON_UNQUOTE(JSON_EXTRACT(`users`.`data`, '$.address.city'))<>?",
+			args:   []any{"Jakarta"},
+			filter: Filter{JSON: JSONMySQL},
+			query:  JSONNe(path, "Jakarta"),
+		},
+		{
+			result: "json_extract(`users`.`data`, '$.tags[0].\"first name\"')>=?",
+			args:   []any{"a"},
+			filter: Filter{JSON: JSONSQLite},
+			query:  JSONCompare(JSONField("data", "tags", "0", "first name"), rel.FilterGteOp, "a"),
+		},
+		{
+			result: "`users`.`data`@>CAST(? AS jsonb)",
+			args:   []any{`{"active":true}`},
+			query:  JSONContains("data", map[string]any{"active": true}),
+		},
+		{
+			result: "JSON_CONTAINS(`users`.`data`, ?)",
+			args:   []any{`["admin"]`},
+			filter: Filter{JSON: JSONMySQL},
+			query:  JSONContains("data", []string{"admin"}),
+		},
+		{
+			result: "(`users`.`data`#>'{\"address\",\"city\"}') IS NOT NULL",
+			query:  JSONHasPath(path),
+		},
+		{
+			result: "JSON_CONTAINS_PATH(`users`.`data`, 'one', '$.address.city')",
+			filter: Filter{JSON: JSONMySQL},
+			query:  JSONHasPath(path),
+		},
+		{
+			result: "json_type(`users`.`data`, '$.address.city') IS NOT NULL",
+			filter: Filter{JSON: JSONSQLite},
+			query:  JSONHasPath(path),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				buffer = bufferFactory.Create()
+			)
+
+			test.filter.Write(&buffer, "users", test.query, queryBuilder)
+
+			assert.Equal(t, test.result, buffer.String())
+			assert.Equal(t, test.args, buffer.Arguments())
+		})
+	}
+}
+
+func TestFilter_WriteJSON_unsupported(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		buffer        = bufferFactory.Create()
+	)
+
+	defer func() {
+		err, _ := recover().(error)
+		assert.True(t, errors.Is(err, ErrUnsupported))
+	}()
+
+	Filter{JSON: JSONSQLite}.Write(&buffer, "users", JSONContains("data", 1), Query{BufferFactory: bufferFactory})
+}
+
+func TestQuery_Build_selectJSON(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, Filter: Filter{}}
+		query         = rel.Build("users", rel.Select("id"), SelectJSON(JSONField("data", "address", "city"), "city"), JSONEq(JSONField("data", "active"), "true"))
+		result, args  = queryBuilder.Build(query)
+	)
+
+	assert.Equal(t, "SELECT \"users\".\"id\",\"users\".\"data\"#>>'{\"address\",\"city\"}' AS \"city\" FROM \"users\" WHERE \"users\".\"data\"#>>'{\"active\"}'=$1;", result)
+	assert.Equal(t, []any{"true"}, args)
+}
+
+func TestUpdate_Build_json(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		mutates       = map[string]rel.Mutate{}
+	)
+
+	for _, mut := range []rel.Mutate{
+		rel.Set("name", "foo"),
+		JSONSet(JSONField("data", "address", "city"), "Jakarta"),
+		JSONRemove(JSONField("data", "nickname")),
+		JSONSet(JSONField("settings", "theme"), "dark"),
+	} {
+		mutates[mut.Field] = mut
+	}
+
+	tests := []struct {
+		result string
+		args   []any
+		filter Filter
+	}{
+		{
+			result: "UPDATE `users` SET `data`=(jsonb_set(`data`, '{\"address\",\"city\"}', CAST(? AS jsonb))#-'{\"nickname\"}'),`name`=?,`settings`=jsonb_set(`settings`, '{\"theme\"}', CAST(? AS jsonb)) WHERE `users`.`id`=?;",
+			args:   []any{`"Jakarta"`, "foo", `"dark"`, 1},
+		},
+		{
+			result: "UPDATE `users` SET `data`=JSON_REMOVE(JSON_SET(`data`, '$.address.city', CAST(? AS JSON)), '$.nickname'),`name`=?,`settings`=JSON_SET(`settings`, '$.theme', CAST(? AS JSON)) WHERE `users`.`id`=?;",
+			args:   []any{`"Jakarta"`, "foo", `"dark"`, 1},
+			filter: Filter{JSON: JSONMySQL},
+		},
+		{
+			result: "UPDATE `users` SET `data`=json_remove(json_set(`data`, '$.address.city', json(?)), '$.nickname'),`name`=?,`settings`=json_set(`settings`, '$.theme', json(?)) WHERE `users`.`id`=?;",
+			args:   []any{`"Jakarta"`, "foo", `"dark"`, 1},
+			filter: Filter{JSON: JSONSQLite},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				updateBuilder = Update{BufferFactory: bufferFactory, Query: Query{BufferFactory: bufferFactory}, Filter: test.filter}
+				result, args  = updateBuilder.Build("users", "id", mutates, rel.Eq("id", 1))
+			)
+
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
+func TestUpdate_Build_jsonOrder(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		updateBuilder = Update{BufferFactory: bufferFactory, Query: Query{BufferFactory: bufferFactory}, Filter: Filter{JSON: JSONMySQL}}
+		path          = JSONField("data", "a")
+		mutates       = map[string]rel.Mutate{}
+	)
+
+	for _, mut := range []rel.Mutate{
+		JSONSet(path, 1),
+		JSONRemove(path),
+		JSONSet(JSONField("data", "b"), 2),
+		JSONSet(JSONField("data", "b"), 3),
+	} {
+		mutates[mut.Field] = mut
+	}
+
+	result, args := updateBuilder.Build("users", "id", mutates, rel.Eq("id", 1))
+	assert.Len(t, mutates, 4)
+	assert.Equal(t, "UPDATE `users` SET `data`=JSON_SET(JSON_SET(JSON_REMOVE(JSON_SET(`data`, '$.a', CAST(? AS JSON)), '$.a'), '$.b', CAST(? AS JSON)), '$.b', CAST(? AS JSON)) WHERE `users`.`id`=?;", result)
+	assert.Equal(t, []any{"1", "2", "3", 1}, args)
+}
@@ -0,0 +1,170 @@
+package builder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-rel/rel"
+)
+
+// MaxCursorLength is the maximum length of token accepted by DecodeCursor.
+const MaxCursorLength = 1024
+
+// ErrInvalidCursor is returned when cursor token can't be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Keyset continues a page after the row identified by values of the sort fields.
+//
+// RowValue writes row value comparison such as (a,b)>(?,?) when all sort fields share the same direction,
+// it's only correct when the sort fields are not nullable.
+type Keyset struct {
+	Sorts    []rel.SortQuery
+	Values   []any
+	RowValue bool
+}
+
+func (k Keyset) filterQuery() rel.FilterQuery {
+	return rel.FilterQuery{
+		Type:  rel.FilterFragmentOp,
+		Field: "KEYSET",
+		Value: []any{k},
+	}
+}
+
+// KeysetAfter filters rows that come after the last row of previous page.
+// values must be the last row's values of each sort field, in the same order.
+func KeysetAfter(sorts []rel.SortQuery, values ...any) rel.FilterQuery {
+	return Keyset{Sorts: sorts, Values: values}.filterQuery()
+}
+
+// KeysetAfterRowValue is like KeysetAfter, but uses row value comparison when possible.
+func KeysetAfterRowValue(sorts []rel.SortQuery, values ...any) rel.FilterQuery {
+	return Keyset{Sorts: sorts, Values: values, RowValue: true}.filterQuery()
+}
+
+// cursorValue is a value of cursor token encoded as string along with its type,
+// so that the value is decoded to the same type it's encoded from.
+type cursorValue struct {
+	Type  string `json:"t"`
+	Value string `json:"v,omitempty"`
+}
+
+// EncodeCursor encodes the last row's values into an opaque url safe token.
+// Supported values are nil, string, []byte, bool, int, int32, int64, uint, uint32, uint64, float32, float64 and time.Time.
+// Token is not signed, the values are readable and can be altered by client, so they must only be used as filter values.
+func EncodeCursor(values ...any) (string, error) {
+	cursor := make([]cursorValue, len(values))
+
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			cursor[i] = cursorValue{Type: "nil"}
+		case string:
+			cursor[i] = cursorValue{Type: "string", Value: v}
+		case []byte:
+			cursor[i] = cursorValue{Type: "bytes", Value: base64.RawStdEncoding.EncodeToString(v)}
+		case bool:
+			cursor[i] = cursorValue{Type: "bool", Value: strconv.FormatBool(v)}
+		case int:
+			cursor[i] = cursorValue{Type: "int", Value: strconv.FormatInt(int64(v), 10)}
+		case int32:
+			cursor[i] = cursorValue{Type: "int32", Value: strconv.FormatInt(int64(v), 10)}
+		case int64:
+			cursor[i] = cursorValue{Type: "int64", Value: strconv.FormatInt(v, 10)}
+		case uint:
+			cursor[i] = cursorValue{Type: "uint", Value: strconv.FormatUint(uint64(v), 10)}
+		case uint32:
+			cursor[i] = cursorValue{Type: "uint32", Value: strconv.FormatUint(uint64(v), 10)}
+		case uint64:
+			cursor[i] = cursorValue{Type: "uint64", Value: strconv.FormatUint(v, 10)}
+		case float32:
+			cursor[i] = cursorValue{Type: "float32", Value: strconv.FormatFloat(float64(v), 'g', -1, 32)}
+		case float64:
+			cursor[i] = cursorValue{Type: "float64", Value: strconv.FormatFloat(v, 'g', -1, 64)}
+		case time.Time:
+			cursor[i] = cursorValue{Type: "time", Value: v.Format(time.RFC3339Nano)}
+		default:
+			return "", fmt.Errorf("unsupported cursor value type %T", value)
+		}
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(data)
+	if len(token) > MaxCursorLength {
+		return "", fmt.Errorf("cursor is longer than %d", MaxCursorLength)
+	}
+
+	return token, nil
+}
+
+// DecodeCursor decodes values encoded by EncodeCursor, ErrInvalidCursor is returned for malformed token.
+func DecodeCursor(token string) ([]any, error) {
+	if len(token) > MaxCursorLength {
+		return nil, fmt.Errorf("%w: longer than %d", ErrInvalidCursor, MaxCursorLength)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var cursor []cursorValue
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	values := make([]any, len(cursor))
+	for i, cv := range cursor {
+		if values[i], err = cv.decode(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+	}
+
+	return values, nil
+}
+
+func (cv cursorValue) decode() (any, error) {
+	switch cv.Type {
+	case "nil":
+		return nil, nil
+	case "string":
+		return cv.Value, nil
+	case "bytes":
+		return base64.RawStdEncoding.DecodeString(cv.Value)
+	case "bool":
+		return strconv.ParseBool(cv.Value)
+	case "int":
+		v, err := strconv.ParseInt(cv.Value, 10, strconv.IntSize)
+		return int(v), err
+	case "int32":
+		v, err := strconv.ParseInt(cv.Value, 10, 32)
+		return int32(v), err
+	case "int64":
+		return strconv.ParseInt(cv.Value, 10, 64)
+	case "uint":
+		v, err := strconv.ParseUint(cv.Value, 10, strconv.IntSize)
+		return uint(v), err
+	case "uint32":
+		v, err := strconv.ParseUint(cv.Value, 10, 32)
+		return uint32(v), err
+	case "uint64":
+		return strconv.ParseUint(cv.Value, 10, 64)
+	case "float32":
+		v, err := strconv.ParseFloat(cv.Value, 32)
+		return float32(v), err
+	case "float64":
+		return strconv.ParseFloat(cv.Value, 64)
+	case "time":
+		return time.Parse(time.RFC3339Nano, cv.Value)
+	}
+
+	return nil, fmt.Errorf("unknown value type %q", cv.Type)
+}
@@ -0,0 +1,56 @@
+package builder
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	var (
+		createdAt = time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	)
+
+	token, err := EncodeCursor(createdAt, 10, int64(11), uint64(12), 1.5, true, "name", []byte("id"), nil)
+	assert.Nil(t, err)
+	assert.NotContains(t, token, "=")
+
+	values, err := DecodeCursor(token)
+	assert.Nil(t, err)
+	assert.Equal(t, []any{createdAt, 10, int64(11), uint64(12), 1.5, true, "name", []byte("id"), nil}, values)
+}
+
+func TestEncodeCursor_unsupported(t *testing.T) {
+	_, err := EncodeCursor(struct{}{})
+	assert.NotNil(t, err)
+
+	_, err = EncodeCursor(strings.Repeat("a", MaxCursorLength))
+	assert.NotNil(t, err)
+}
+
+func TestDecodeCursor_invalid(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []string{
+		"!",
+		"aW52YWxpZA",
+		encode(`{"t":"int","v":"1"}`),
+		encode(`[{"t":"int","v":"one"}]`),
+		encode(`[{"t":"int32","v":"4294967296"}]`),
+		encode(`[{"t":"time","v":"yesterday"}]`),
+		encode(`[{"t":"func"}]`),
+		strings.Repeat("a", MaxCursorLength+1),
+	}
+
+	for _, token := range tests {
+		t.Run(token, func(t *testing.T) {
+			_, err := DecodeCursor(token)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
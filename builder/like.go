@@ -0,0 +1,126 @@
+package builder
+
+import (
+	"strings"
+
+	"github.com/go-rel/rel"
+)
+
+// LikeEscapeCharacter is the default character used to escape wildcard in LIKE pattern.
+const LikeEscapeCharacter = '\\'
+
+// LikeMatch defines how value of LikeFilter is matched.
+type LikeMatch int
+
+const (
+	// LikePattern matches value as a pattern, wildcards are not escaped.
+	LikePattern LikeMatch = iota
+	// LikeContains matches text that contains value.
+	LikeContains
+	// LikePrefix matches text that starts with value.
+	LikePrefix
+	// LikeSuffix matches text that ends with value.
+	LikeSuffix
+)
+
+// LikeFilter matches field using LIKE operator.
+type LikeFilter struct {
+	Field           string
+	Value           string
+	Match           LikeMatch
+	CaseInsensitive bool
+	Not             bool
+}
+
+func (lf LikeFilter) filterQuery() rel.FilterQuery {
+	return rel.FilterQuery{
+		Type:  rel.FilterFragmentOp,
+		Field: lf.Field,
+		Value: []any{lf},
+	}
+}
+
+// pattern returns value with wildcards added according to match.
+func (lf LikeFilter) pattern(escape byte) string {
+	switch lf.Match {
+	case LikeContains:
+		return "%" + EscapeLikeWith(lf.Value, escape) + "%"
+	case LikePrefix:
+		return EscapeLikeWith(lf.Value, escape) + "%"
+	case LikeSuffix:
+		return "%" + EscapeLikeWith(lf.Value, escape)
+	}
+
+	return lf.Value
+}
+
+// Contains matches field that contains text, wildcards in text are escaped.
+func Contains(field string, text string) rel.FilterQuery {
+	return LikeFilter{Field: field, Value: text, Match: LikeContains}.filterQuery()
+}
+
+// NotContains matches field that doesn't contain text, wildcards in text are escaped.
+func NotContains(field string, text string) rel.FilterQuery {
+	return LikeFilter{Field: field, Value: text, Match: LikeContains, Not: true}.filterQuery()
+}
+
+// StartsWith matches field that starts with text, wildcards in text are escaped.
+func StartsWith(field string, text string) rel.FilterQuery {
+	return LikeFilter{Field: field, Value: text, Match: LikePrefix}.filterQuery()
+}
+
+// EndsWith matches field that ends with text, wildcards in text are escaped.
+func EndsWith(field string, text string) rel.FilterQuery {
+	return LikeFilter{Field: field, Value: text, Match: LikeSuffix}.filterQuery()
+}
+
+// ILike matches field against pattern case-insensitively.
+func ILike(field string, pattern string) rel.FilterQuery {
+	return LikeFilter{Field: field, Value: pattern, CaseInsensitive: true}.filterQuery()
+}
+
+// NotILike matches field that doesn't match pattern case-insensitively.
+func NotILike(field string, pattern string) rel.FilterQuery {
+	return LikeFilter{Field: field, Value: pattern, CaseInsensitive: true, Not: true}.filterQuery()
+}
+
+// IContains matches field that contains text case-insensitively, wildcards in text are escaped.
+func IContains(field string, text string) rel.FilterQuery {
+	return LikeFilter{Field: field, Value: text, Match: LikeContains, CaseInsensitive: true}.filterQuery()
+}
+
+// IStartsWith matches field that starts with text case-insensitively, wildcards in text are escaped.
+func IStartsWith(field string, text string) rel.FilterQuery {
+	return LikeFilter{Field: field, Value: text, Match: LikePrefix, CaseInsensitive: true}.filterQuery()
+}
+
+// IEndsWith matches field that ends with text case-insensitively, wildcards in text are escaped.
+func IEndsWith(field string, text string) rel.FilterQuery {
+	return LikeFilter{Field: field, Value: text, Match: LikeSuffix, CaseInsensitive: true}.filterQuery()
+}
+
+// EscapeLike escapes %, _ and escape character in text, so it's matched literally by LIKE operator.
+func EscapeLike(text string) string {
+	return EscapeLikeWith(text, LikeEscapeCharacter)
+}
+
+// EscapeLikeWith escapes %, _ and the given escape character in text.
+func EscapeLikeWith(text string, escape byte) string {
+	if !strings.ContainsAny(text, "%_") && strings.IndexByte(text, escape) < 0 {
+		return text
+	}
+
+	var (
+		builder strings.Builder
+	)
+
+	builder.Grow(len(text) + 2)
+	for i := 0; i < len(text); i++ {
+		if c := text[i]; c == '%' || c == '_' || c == escape {
+			builder.WriteByte(escape)
+		}
+		builder.WriteByte(text[i])
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,207 @@
+package builder
+
+import (
+	"log"
+	"strings"
+
+	"github.com/go-rel/rel"
+)
+
+// Lock strengths and wait policies of RowLock.
+const (
+	LockUpdate      = "UPDATE"
+	LockNoKeyUpdate = "NO KEY UPDATE"
+	LockShare       = "SHARE"
+	LockKeyShare    = "KEY SHARE"
+
+	LockNoWait     = "NOWAIT"
+	LockSkipLocked = "SKIP LOCKED"
+)
+
+// RowLock is a structured row locking clause.
+//
+// rel.Lock is a plain string, so RowLock is stored in its canonical form: FOR strength [OF table, ...] [wait],
+// and parsed back by Locking builder to be written for each dialect.
+type RowLock struct {
+	Strength string
+	Wait     string
+	Of       []string
+}
+
+// String returns canonical form of the lock.
+func (rl RowLock) String() string {
+	var (
+		builder strings.Builder
+	)
+
+	builder.WriteString("FOR ")
+	if rl.Strength == "" {
+		builder.WriteString(LockUpdate)
+	} else {
+		builder.WriteString(rl.Strength)
+	}
+
+	if len(rl.Of) > 0 {
+		builder.WriteString(" OF ")
+		builder.WriteString(strings.Join(rl.Of, ", "))
+	}
+
+	if rl.Wait != "" {
+		builder.WriteByte(' ')
+		builder.WriteString(rl.Wait)
+	}
+
+	return builder.String()
+}
+
+// Lock returns rel.Lock that can be used as a query.
+func (rl RowLock) Lock() rel.Lock {
+	return rel.Lock(rl.String())
+}
+
+// ForUpdate locks selected rows for update, optionally only rows from the given tables.
+func ForUpdate(of ...string) RowLock {
+	return RowLock{Strength: LockUpdate, Of: of}
+}
+
+// ForShare locks selected rows from being updated, optionally only rows from the given tables.
+func ForShare(of ...string) RowLock {
+	return RowLock{Strength: LockShare, Of: of}
+}
+
+// NoWait fails the query instead of waiting for locked rows.
+func (rl RowLock) NoWait() RowLock {
+	rl.Wait = LockNoWait
+	return rl
+}
+
+// SkipLocked skips locked rows instead of waiting for them.
+func (rl RowLock) SkipLocked() RowLock {
+	rl.Wait = LockSkipLocked
+	return rl
+}
+
+// parseRowLock parses canonical form of the lock, false is returned when lock is not in canonical form.
+func parseRowLock(lock string) (RowLock, bool) {
+	var (
+		rl   RowLock
+		rest = strings.TrimSpace(lock)
+	)
+
+	if !strings.HasPrefix(rest, "FOR ") {
+		return rl, false
+	}
+	rest = rest[len("FOR "):]
+
+	for _, strength := range []string{LockNoKeyUpdate, LockKeyShare, LockUpdate, LockShare} {
+		if rest == strength || strings.HasPrefix(rest, strength+" ") {
+			rl.Strength = strength
+			rest = strings.TrimPrefix(rest[len(strength):], " ")
+			break
+		}
+	}
+
+	if rl.Strength == "" {
+		return rl, false
+	}
+
+	for _, wait := range []string{LockNoWait, LockSkipLocked} {
+		if rest == wait || strings.HasSuffix(rest, " "+wait) {
+			rl.Wait = wait
+			rest = strings.TrimSuffix(strings.TrimSuffix(rest, wait), " ")
+			break
+		}
+	}
+
+	if rest != "" {
+		if !strings.HasPrefix(rest, "OF ") {
+			return rl, false
+		}
+
+		for _, table := range strings.Split(rest[len("OF "):], ",") {
+			if table = strings.TrimSpace(table); table != "" {
+				rl.Of = append(rl.Of, table)
+			}
+		}
+	}
+
+	return rl, true
+}
+
+// Locking builder.
+//
+// Zero value writes every lock clause natively, as supported by PostgreSQL.
+type Locking struct {
+	// Disabled excludes lock clause with a warning, for dialect without row locking such as SQLite.
+	Disabled bool
+	// ShareStatement replaces FOR SHARE, for example LOCK IN SHARE MODE for MySQL 5.7.
+	// OF tables, NOWAIT and SKIP LOCKED can't follow the statement, so they're excluded with a warning.
+	ShareStatement string
+	// NoKeyStrength writes NO KEY UPDATE and KEY SHARE as UPDATE and SHARE, for MySQL.
+	NoKeyStrength bool
+	// NoOf excludes OF tables, which locks rows from every table instead.
+	NoOf bool
+	// NoWait excludes NOWAIT and SKIP LOCKED with a warning, for MySQL 5.7.
+	NoWait bool
+}
+
+// Write SQL to buffer.
+func (l Locking) Write(buffer *Buffer, lock rel.Lock) {
+	if lock == "" {
+		return
+	}
+
+	if l.Disabled {
+		log.Printf("[REL] Adapter does not support row locking, lock is excluded: %s", lock)
+		return
+	}
+
+	rl, ok := parseRowLock(string(lock))
+	if !ok {
+		buffer.WriteByte(' ')
+		buffer.WriteString(string(lock))
+		return
+	}
+
+	strength := rl.Strength
+	if l.NoKeyStrength {
+		switch strength {
+		case LockNoKeyUpdate:
+			strength = LockUpdate
+		case LockKeyShare:
+			strength = LockShare
+		}
+	}
+
+	buffer.WriteByte(' ')
+	if strength == LockShare && l.ShareStatement != "" {
+		buffer.WriteString(l.ShareStatement)
+		if len(rl.Of) > 0 || rl.Wait != "" {
+			log.Printf("[REL] Adapter does not support OF and wait policy with %s, they're excluded from lock: %s", l.ShareStatement, lock)
+		}
+		return
+	}
+
+	buffer.WriteString("FOR ")
+	buffer.WriteString(strength)
+
+	if len(rl.Of) > 0 && !l.NoOf {
+		buffer.WriteString(" OF ")
+		for i, table := range rl.Of {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			buffer.WriteEscape(table)
+		}
+	}
+
+	if rl.Wait != "" {
+		if l.NoWait {
+			log.Printf("[REL] Adapter does not support %s, it's excluded from lock", rl.Wait)
+			return
+		}
+
+		buffer.WriteByte(' ')
+		buffer.WriteString(rl.Wait)
+	}
+}
@@ -0,0 +1,68 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowLock_String(t *testing.T) {
+	assert.Equal(t, "FOR UPDATE", ForUpdate().String())
+	assert.Equal(t, "FOR UPDATE", RowLock{}.String())
+	assert.Equal(t, "FOR UPDATE SKIP LOCKED", ForUpdate().SkipLocked().String())
+	assert.Equal(t, "FOR SHARE OF users, posts NOWAIT", ForShare("users", "posts").NoWait().String())
+	assert.Equal(t, rel.Lock("FOR NO KEY UPDATE"), RowLock{Strength: LockNoKeyUpdate}.Lock())
+	assert.Equal(t, rel.ForUpdate(), ForUpdate().Lock())
+}
+
+func TestLocking_Write(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		mysql         = Locking{NoKeyStrength: true}
+		mysql57       = Locking{ShareStatement: "LOCK IN SHARE MODE", NoKeyStrength: true, NoOf: true, NoWait: true}
+		sqlite        = Locking{Disabled: true}
+	)
+
+	tests := []struct {
+		result  string
+		locking Locking
+		lock    rel.Lock
+	}{
+		{result: "", lock: ""},
+		{result: " FOR UPDATE", lock: ForUpdate().Lock()},
+		{result: " FOR UPDATE SKIP LOCKED", lock: ForUpdate().SkipLocked().Lock()},
+		{result: " FOR NO KEY UPDATE OF `jobs` NOWAIT", lock: RowLock{Strength: LockNoKeyUpdate, Wait: LockNoWait, Of: []string{"jobs"}}.Lock()},
+		{result: " FOR KEY SHARE OF `jobs`, `users`", lock: RowLock{Strength: LockKeyShare, Of: []string{"jobs", "users"}}.Lock()},
+		{result: " FOR UPDATE SKIP LOCKED", lock: "FOR UPDATE SKIP LOCKED"},
+		{result: " LOCK IN SHARE MODE", lock: "LOCK IN SHARE MODE"},
+		{result: " FOR UPDATEX", lock: "FOR UPDATEX"},
+		{result: " FOR UPDATE OF `jobs` SKIP LOCKED", locking: mysql, lock: RowLock{Strength: LockNoKeyUpdate, Wait: LockSkipLocked, Of: []string{"jobs"}}.Lock()},
+		{result: " FOR SHARE", locking: mysql, lock: RowLock{Strength: LockKeyShare}.Lock()},
+		{result: " LOCK IN SHARE MODE", locking: Locking{ShareStatement: "LOCK IN SHARE MODE"}, lock: ForShare("jobs").SkipLocked().Lock()},
+		{result: " LOCK IN SHARE MODE", locking: Locking{ShareStatement: "LOCK IN SHARE MODE", NoKeyStrength: true}, lock: RowLock{Strength: LockKeyShare, Wait: LockNoWait}.Lock()},
+		{result: " LOCK IN SHARE MODE", locking: mysql57, lock: ForShare("jobs").NoWait().Lock()},
+		{result: " FOR UPDATE", locking: mysql57, lock: ForUpdate().SkipLocked().Lock()},
+		{result: "", locking: sqlite, lock: ForUpdate().SkipLocked().Lock()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			buffer := bufferFactory.Create()
+			test.locking.Write(&buffer, test.lock)
+			assert.Equal(t, test.result, buffer.String())
+		})
+	}
+}
+
+func TestQuery_Build_lock(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, Filter: Filter{}}
+		query         = rel.Build("jobs", rel.Where(rel.Eq("status", "pending")), rel.SortAsc("id"), rel.Limit(1), ForUpdate().SkipLocked().Lock())
+		result, args  = queryBuilder.Build(query)
+	)
+
+	assert.Equal(t, "SELECT \"jobs\".* FROM \"jobs\" WHERE \"jobs\".\"status\"=$1 ORDER BY \"jobs\".\"id\" ASC LIMIT 1 FOR UPDATE SKIP LOCKED;", result)
+	assert.Equal(t, []any{"pending"}, args)
+}
@@ -37,12 +37,7 @@ func (oc OnConflict) Write(buffer *Buffer, fields []string, onConflict rel.OnCon
 func (oc OnConflict) WriteMutates(buffer *Buffer, mutates map[string]rel.Mutate, onConflict rel.OnConflict) {
 	var fields []string
 	if onConflict.Replace || (onConflict.Ignore && oc.IgnoreStatement == "") {
-		fields = make([]string, len(mutates))
-		i := 0
-		for field := range mutates {
-			fields[i] = field
-			i++
-		}
+		fields = mutatesFields(mutates, false)
 	}
 	oc.Write(buffer, fields, onConflict)
 }
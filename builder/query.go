@@ -11,10 +11,41 @@ type QueryWriter interface {
 	Write(buffer *Buffer, query rel.Query)
 }
 
+// Pagination defines how LIMIT and OFFSET is written.
+type Pagination int
+
+const (
+	// LimitOffset writes LIMIT n OFFSET m.
+	LimitOffset Pagination = iota
+	// OffsetFetch writes standard OFFSET m ROWS FETCH NEXT n ROWS ONLY.
+	OffsetFetch
+	// Top writes SELECT TOP n, OffsetFetch is used instead when query has offset or compound clause.
+	Top
+)
+
+const (
+	// UnboundedLimitMySQL is the unbounded limit for MySQL, which is the maximum value of unsigned big integer.
+	UnboundedLimitMySQL = "18446744073709551615"
+	// UnboundedLimitSQLite is the unbounded limit for SQLite.
+	UnboundedLimitSQLite = "-1"
+)
+
 // Query builder.
 type Query struct {
 	BufferFactory BufferFactory
 	Filter        Filter
+	Pagination    Pagination
+	// UnboundedLimit is written as LIMIT when query only has OFFSET, for dialect that requires LIMIT before OFFSET
+	// such as UnboundedLimitMySQL and UnboundedLimitSQLite. OFFSET is written alone when it's empty.
+	UnboundedLimit string
+	// FallbackOrderBy is written as ORDER BY when OFFSET ... FETCH is used without sort, because it's required by dialect such as SQL Server.
+	// For example (SELECT NULL).
+	FallbackOrderBy string
+	// EmulateNullsOrder writes NULLS FIRST and NULLS LAST using CASE expression, for dialect such as MySQL and SQL Server.
+	EmulateNullsOrder bool
+	Locking           Locking
+	// JoinResolver resolves join condition of join without explicit From and To, JoinNaming is used when it's nil.
+	JoinResolver JoinResolver
 }
 
 // Build SQL string and it arguments.
@@ -26,6 +57,31 @@ func (q Query) Build(query rel.Query) (string, []any) {
 	return buffer.String(), buffer.Arguments()
 }
 
+// BuildAggregate builds SQL string and its arguments that select aggregate of field as result.
+// Select fields and named windows carried by join queries are left out, since only the aggregate is selected.
+// Query with set operation is aggregated as a derived table, since the aggregate applies to the combined result.
+func (q Query) BuildAggregate(query rel.Query, mode string, field string) (string, []any) {
+	aggregateField := mode + "(" + field + ") AS result"
+
+	if hasCompound(query.JoinQuery) {
+		buffer := q.BufferFactory.Create()
+
+		buffer.WriteString("SELECT ")
+		buffer.WriteString(aggregateField)
+		buffer.WriteString(" FROM (")
+		q.Write(&buffer, query)
+		buffer.WriteString(") AS t;")
+
+		return buffer.String(), buffer.Arguments()
+	}
+
+	aggregateField = "^" + aggregateField
+	query = query.Select(append([]string{aggregateField}, query.GroupQuery.Fields...)...)
+	query.JoinQuery = withoutSelectClause(query.JoinQuery)
+
+	return q.Build(query)
+}
+
 // Write SQL to buffer.
 func (q Query) Write(buffer *Buffer, query rel.Query) {
 	if query.SQLQuery.Statement != "" {
@@ -36,7 +92,9 @@ func (q Query) Write(buffer *Buffer, query rel.Query) {
 
 	rootQuery := buffer.Len() == 0
 
-	q.WriteSelect(buffer, query.Table, query.SelectQuery)
+	q.WriteWith(buffer, query.JoinQuery)
+	q.writeSelect(buffer, query.Table, query.SelectQuery, q.selectTop(query))
+	q.WriteSelectClause(buffer, query.Table, query.JoinQuery)
 	q.WriteQuery(buffer, query)
 
 	if rootQuery {
@@ -44,23 +102,82 @@ func (q Query) Write(buffer *Buffer, query rel.Query) {
 	}
 }
 
-// WriteSelect SQL to buffer.
-func (q Query) WriteSelect(buffer *Buffer, table string, selectQuery rel.SelectQuery) {
-	if len(selectQuery.Fields) == 0 {
-		buffer.WriteString("SELECT ")
-		if selectQuery.OnlyDistinct {
-			buffer.WriteString("DISTINCT ")
+// WriteWith SQL to buffer.
+func (q Query) WriteWith(buffer *Buffer, joins []rel.JoinQuery) {
+	var (
+		ctes      []CTE
+		recursive bool
+	)
+
+	for _, join := range joins {
+		if cte, ok := queryClause(join).(CTE); ok {
+			ctes = append(ctes, cte)
+			recursive = recursive || cte.Recursive
 		}
-		buffer.WriteField(table, "*")
+	}
+
+	if len(ctes) == 0 {
 		return
 	}
 
+	buffer.WriteString("WITH ")
+	if recursive {
+		buffer.WriteString("RECURSIVE ")
+	}
+
+	for i, cte := range ctes {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		buffer.WriteTable(cte.Name)
+
+		if len(cte.Columns) > 0 {
+			buffer.WriteString(" (")
+			for j, col := range cte.Columns {
+				if j > 0 {
+					buffer.WriteByte(',')
+				}
+				buffer.WriteEscape(col)
+			}
+			buffer.WriteByte(')')
+		}
+
+		buffer.WriteString(" AS (")
+		q.Write(buffer, cte.Query)
+		if cte.Recursive {
+			buffer.WriteString(" UNION ALL ")
+			q.Write(buffer, cte.RecursiveQuery)
+		}
+		buffer.WriteByte(')')
+	}
+
+	buffer.WriteByte(' ')
+}
+
+// WriteSelect SQL to buffer.
+func (q Query) WriteSelect(buffer *Buffer, table string, selectQuery rel.SelectQuery) {
+	q.writeSelect(buffer, table, selectQuery, 0)
+}
+
+func (q Query) writeSelect(buffer *Buffer, table string, selectQuery rel.SelectQuery, top rel.Limit) {
 	buffer.WriteString("SELECT ")
 
 	if selectQuery.OnlyDistinct {
 		buffer.WriteString("DISTINCT ")
 	}
 
+	if top > 0 {
+		buffer.WriteString("TOP ")
+		buffer.WriteString(strconv.Itoa(int(top)))
+		buffer.WriteByte(' ')
+	}
+
+	if len(selectQuery.Fields) == 0 {
+		buffer.WriteField(table, "*")
+		return
+	}
+
 	l := len(selectQuery.Fields) - 1
 	for i, f := range selectQuery.Fields {
 		buffer.WriteField(table, f)
@@ -71,6 +188,112 @@ func (q Query) WriteSelect(buffer *Buffer, table string, selectQuery rel.SelectQ
 	}
 }
 
+// WriteSelectClause writes select fields carried by join queries to buffer.
+func (q Query) WriteSelectClause(buffer *Buffer, table string, joins []rel.JoinQuery) {
+	for _, join := range joins {
+		switch v := queryClause(join).(type) {
+		case WindowFunction:
+			buffer.WriteByte(',')
+			q.WriteWindowFunction(buffer, table, v)
+			buffer.WriteString(" OVER ")
+			q.WriteWindowSpec(buffer, table, v.Window)
+			buffer.WriteString(" AS ")
+			buffer.WriteEscape(v.Alias)
+		case SubQueryField:
+			buffer.WriteString(",(")
+			q.Write(buffer, v.Query)
+			buffer.WriteString(") AS ")
+			buffer.WriteEscape(v.Alias)
+		case ExpressionField:
+			buffer.WriteByte(',')
+			buffer.WriteExpression(v.Expression, v.Values...)
+			buffer.WriteString(" AS ")
+			buffer.WriteEscape(v.Alias)
+		case JSONExtractField:
+			buffer.WriteByte(',')
+			q.Filter.WriteJSONExtract(buffer, table, v.Path)
+			buffer.WriteString(" AS ")
+			buffer.WriteEscape(v.Alias)
+		case MatchRankField:
+			buffer.WriteByte(',')
+			q.Filter.WriteMatchRank(buffer, table, v.matchFilter())
+			buffer.WriteString(" AS ")
+			buffer.WriteEscape(v.Alias)
+		}
+	}
+}
+
+// WriteWindowFunction writes window function call without its window to buffer.
+func (q Query) WriteWindowFunction(buffer *Buffer, table string, function WindowFunction) {
+	buffer.WriteString(function.Function)
+	buffer.WriteByte('(')
+
+	if function.Distinct {
+		buffer.WriteString("DISTINCT ")
+	}
+
+	for i, arg := range function.Arguments {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		switch v := arg.(type) {
+		case string:
+			if v == "*" {
+				buffer.WriteString(v)
+			} else {
+				buffer.WriteField(table, v)
+			}
+		default:
+			buffer.WriteValue(v)
+		}
+	}
+
+	buffer.WriteByte(')')
+}
+
+// WriteWindowSpec SQL to buffer.
+func (q Query) WriteWindowSpec(buffer *Buffer, table string, window Window) {
+	if window.Name != "" && len(window.PartitionBy) == 0 && len(window.OrderBy) == 0 && window.Frame == "" {
+		buffer.WriteEscape(window.Name)
+		return
+	}
+
+	buffer.WriteByte('(')
+	sep := ""
+
+	if window.Name != "" {
+		buffer.WriteEscape(window.Name)
+		sep = " "
+	}
+
+	if len(window.PartitionBy) > 0 {
+		buffer.WriteString(sep)
+		buffer.WriteString("PARTITION BY ")
+		for i, f := range window.PartitionBy {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			buffer.WriteField(table, f)
+		}
+		sep = " "
+	}
+
+	if len(window.OrderBy) > 0 {
+		buffer.WriteString(sep)
+		buffer.WriteString("ORDER BY ")
+		q.WriteSorts(buffer, table, window.OrderBy)
+		sep = " "
+	}
+
+	if window.Frame != "" {
+		buffer.WriteString(sep)
+		buffer.WriteString(window.Frame)
+	}
+
+	buffer.WriteByte(')')
+}
+
 // WriteQuery SQL to buffer.
 func (q Query) WriteQuery(buffer *Buffer, query rel.Query) {
 	q.WriteFrom(buffer, query.Table)
@@ -82,13 +305,25 @@ func (q Query) WriteQuery(buffer *Buffer, query rel.Query) {
 		q.WriteHaving(buffer, query.Table, query.GroupQuery.Filter)
 	}
 
-	q.WriteOrderBy(buffer, query.Table, query.SortQuery)
-	q.WriteLimitOffset(buffer, query.LimitQuery, query.OffsetQuery)
+	q.WriteWindow(buffer, query.Table, query.JoinQuery)
 
-	if query.LockQuery != "" {
-		buffer.WriteByte(' ')
-		buffer.WriteString(string(query.LockQuery))
+	if q.WriteCompound(buffer, query.JoinQuery) {
+		// sort applies to the combined result, which can only be referenced by column name.
+		q.WriteOrderBy(buffer, "", query.SortQuery)
+	} else {
+		q.WriteOrderBy(buffer, query.Table, q.aliasSorts(buffer, query.JoinQuery, query.SortQuery))
+	}
+
+	if q.selectTop(query) == 0 {
+		if len(query.SortQuery) == 0 && q.FallbackOrderBy != "" && q.offsetFetch(query.LimitQuery, query.OffsetQuery) {
+			buffer.WriteString(" ORDER BY ")
+			buffer.WriteString(q.FallbackOrderBy)
+		}
+
+		q.WriteLimitOffset(buffer, query.LimitQuery, query.OffsetQuery)
 	}
+
+	q.Locking.Write(buffer, query.LockQuery)
 }
 
 // WriteFrom SQL to buffer.
@@ -104,17 +339,26 @@ func (q Query) WriteJoin(buffer *Buffer, table string, joins []rel.JoinQuery) {
 	}
 
 	for _, join := range joins {
+		if queryClause(join) != nil {
+			continue
+		}
+
+		if clause := joinClause(join); clause != nil {
+			q.WriteJoinClause(buffer, clause)
+			continue
+		}
+
 		var (
-			_, sAlias      = extractAlias(table)
+			sTable, sAlias = extractAlias(table)
 			jTable, jAlias = extractAlias(join.Table)
 			from           = join.From
 			to             = join.To
 		)
 
-		// TODO: move this to core functionality, and infer join condition using assoc data.
 		if join.Arguments == nil && (join.From == "" || join.To == "") {
-			from = sAlias + "." + strings.TrimSuffix(jTable, "s") + "_id"
-			to = jAlias + ".id"
+			from, to = q.resolveJoin(sTable, jTable)
+			from = sAlias + "." + from
+			to = jAlias + "." + to
 		}
 
 		buffer.WriteByte(' ')
@@ -137,6 +381,44 @@ func (q Query) WriteJoin(buffer *Buffer, table string, joins []rel.JoinQuery) {
 	}
 }
 
+// WriteJoinClause writes join carried by join query to buffer.
+func (q Query) WriteJoinClause(buffer *Buffer, clause any) {
+	switch v := clause.(type) {
+	case UsingJoin:
+		buffer.WriteByte(' ')
+		buffer.WriteString(v.Mode)
+		buffer.WriteByte(' ')
+		buffer.WriteTable(v.Table)
+		buffer.WriteString(" USING (")
+		for i, col := range v.Columns {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			buffer.WriteEscape(col)
+		}
+		buffer.WriteByte(')')
+	case SubQueryJoin:
+		buffer.WriteByte(' ')
+		buffer.WriteString(v.Mode)
+		if v.Lateral {
+			buffer.WriteString(" LATERAL")
+		}
+		buffer.WriteString(" (")
+		q.Write(buffer, v.Query)
+		buffer.WriteString(") AS ")
+		buffer.WriteEscape(v.Alias)
+
+		if !strings.Contains(strings.ToUpper(v.Mode), "CROSS") {
+			buffer.WriteString(" ON ")
+			if v.Filter.None() {
+				buffer.WriteString("1=1")
+			} else {
+				q.Filter.Write(buffer, v.Alias, v.Filter, q)
+			}
+		}
+	}
+}
+
 // WriteWhere SQL to buffer.
 func (q Query) WriteWhere(buffer *Buffer, table string, filter rel.FilterQuery) {
 	if filter.None() {
@@ -171,6 +453,73 @@ func (q Query) WriteHaving(buffer *Buffer, table string, filter rel.FilterQuery)
 	q.Filter.Write(buffer, table, filter, q)
 }
 
+// WriteWindow SQL to buffer.
+func (q Query) WriteWindow(buffer *Buffer, table string, joins []rel.JoinQuery) {
+	n := 0
+
+	for _, join := range joins {
+		definition, ok := queryClause(join).(WindowDefinition)
+		if !ok {
+			continue
+		}
+
+		if n == 0 {
+			buffer.WriteString(" WINDOW ")
+		} else {
+			buffer.WriteByte(',')
+		}
+
+		buffer.WriteEscape(definition.Name)
+		buffer.WriteString(" AS ")
+		q.WriteWindowSpec(buffer, table, definition.Window)
+		n++
+	}
+}
+
+// WriteCompound SQL to buffer, returns true if any set operation is written.
+func (q Query) WriteCompound(buffer *Buffer, joins []rel.JoinQuery) bool {
+	written := false
+
+	for _, join := range joins {
+		compound, ok := queryClause(join).(Compound)
+		if !ok {
+			continue
+		}
+
+		buffer.WriteByte(' ')
+		buffer.WriteString(compound.Operator)
+		buffer.WriteByte(' ')
+
+		// parentheses are only written when required, since some database doesn't support them.
+		if compoundParentheses(compound.Query) {
+			buffer.WriteByte('(')
+			q.Write(buffer, compound.Query)
+			buffer.WriteByte(')')
+		} else {
+			q.Write(buffer, compound.Query)
+		}
+
+		written = true
+	}
+
+	return written
+}
+
+func compoundParentheses(query rel.Query) bool {
+	if len(query.SortQuery) > 0 || query.LimitQuery > 0 || query.OffsetQuery > 0 {
+		return true
+	}
+
+	for _, join := range query.JoinQuery {
+		switch queryClause(join).(type) {
+		case CTE, Compound:
+			return true
+		}
+	}
+
+	return false
+}
+
 // WriteOrderBy SQL to buffer.
 func (q Query) WriteOrderBy(buffer *Buffer, table string, orders []rel.SortQuery) {
 	length := len(orders)
@@ -180,11 +529,27 @@ func (q Query) WriteOrderBy(buffer *Buffer, table string, orders []rel.SortQuery
 	}
 
 	buffer.WriteString(" ORDER BY ")
+	q.WriteSorts(buffer, table, orders)
+}
+
+// WriteSorts writes comma separated sort fields to buffer.
+func (q Query) WriteSorts(buffer *Buffer, table string, orders []rel.SortQuery) {
 	for i, order := range orders {
 		if i > 0 {
 			buffer.WriteString(", ")
 		}
 
+		nulls := nullsOrder(order)
+		if nulls != 0 && q.EmulateNullsOrder {
+			buffer.WriteString("CASE WHEN ")
+			buffer.WriteField(table, order.Field)
+			if nulls == sortNullsFirst {
+				buffer.WriteString(" IS NULL THEN 0 ELSE 1 END, ")
+			} else {
+				buffer.WriteString(" IS NULL THEN 1 ELSE 0 END, ")
+			}
+		}
+
 		buffer.WriteField(table, order.Field)
 
 		if order.Asc() {
@@ -192,18 +557,158 @@ func (q Query) WriteOrderBy(buffer *Buffer, table string, orders []rel.SortQuery
 		} else {
 			buffer.WriteString(" DESC")
 		}
+
+		if nulls != 0 && !q.EmulateNullsOrder {
+			if nulls == sortNullsFirst {
+				buffer.WriteString(" NULLS FIRST")
+			} else {
+				buffer.WriteString(" NULLS LAST")
+			}
+		}
 	}
 }
 
+// aliasSorts returns sorts where the field that refers to alias of select clause is not qualified by table name.
+func (q Query) aliasSorts(buffer *Buffer, joins []rel.JoinQuery, orders []rel.SortQuery) []rel.SortQuery {
+	var (
+		aliases map[string]bool
+		result  []rel.SortQuery
+	)
+
+	for _, join := range joins {
+		var alias string
+		switch v := queryClause(join).(type) {
+		case WindowFunction:
+			alias = v.Alias
+		case SubQueryField:
+			alias = v.Alias
+		case ExpressionField:
+			alias = v.Alias
+		case JSONExtractField:
+			alias = v.Alias
+		case MatchRankField:
+			alias = v.Alias
+		default:
+			continue
+		}
+
+		if aliases == nil {
+			aliases = make(map[string]bool)
+		}
+		aliases[alias] = true
+	}
+
+	if aliases == nil {
+		return orders
+	}
+
+	result = make([]rel.SortQuery, len(orders))
+	for i, order := range orders {
+		if aliases[order.Field] {
+			order.Field = string(UnescapeCharacter) + buffer.Quoter.ID(order.Field)
+		}
+		result[i] = order
+	}
+
+	return result
+}
+
 // WriteLimitOffset SQL to buffer.
 func (q Query) WriteLimitOffset(buffer *Buffer, limit rel.Limit, offset rel.Offset) {
+	if q.Pagination == OffsetFetch || q.Pagination == Top {
+		q.writeOffsetFetch(buffer, limit, offset)
+		return
+	}
+
 	if limit > 0 {
 		buffer.WriteString(" LIMIT ")
 		buffer.WriteString(strconv.Itoa(int(limit)))
+	} else if offset > 0 && q.UnboundedLimit != "" {
+		buffer.WriteString(" LIMIT ")
+		buffer.WriteString(q.UnboundedLimit)
+	}
+
+	if offset > 0 {
+		buffer.WriteString(" OFFSET ")
+		buffer.WriteString(strconv.Itoa(int(offset)))
+	}
+}
 
-		if offset > 0 {
-			buffer.WriteString(" OFFSET ")
-			buffer.WriteString(strconv.Itoa(int(offset)))
+// offsetFetch reports whether limit and offset is written using OFFSET ... FETCH.
+func (q Query) offsetFetch(limit rel.Limit, offset rel.Offset) bool {
+	return (q.Pagination == OffsetFetch || q.Pagination == Top) && (limit > 0 || offset > 0)
+}
+
+// selectTop returns limit to be written as SELECT TOP, zero when it's not applicable.
+func (q Query) selectTop(query rel.Query) rel.Limit {
+	if q.Pagination != Top || query.LimitQuery <= 0 || query.OffsetQuery > 0 || hasCompound(query.JoinQuery) {
+		return 0
+	}
+
+	return query.LimitQuery
+}
+
+func (q Query) writeOffsetFetch(buffer *Buffer, limit rel.Limit, offset rel.Offset) {
+	if limit <= 0 && offset <= 0 {
+		return
+	}
+
+	buffer.WriteString(" OFFSET ")
+	buffer.WriteString(strconv.Itoa(int(offset)))
+	buffer.WriteString(" ROWS")
+
+	if limit > 0 {
+		buffer.WriteString(" FETCH NEXT ")
+		buffer.WriteString(strconv.Itoa(int(limit)))
+		buffer.WriteString(" ROWS ONLY")
+	}
+}
+
+// queryClause returns query part carried by join query, nil is returned for regular join.
+//
+// rel.Query only has a place for the clauses supported by rel, and rel.Build only passes rel's own types through.
+// Query parts defined by this package, such as CTE, set operation, named window and computed select field,
+// are carried as the only argument of a join query, so they're kept by rel and written by Query builder instead of JOIN clause.
+// Select fields and named windows are left out by BuildAggregate, since only the aggregate is selected.
+func queryClause(join rel.JoinQuery) any {
+	if len(join.Arguments) != 1 {
+		return nil
+	}
+
+	switch v := join.Arguments[0].(type) {
+	case CTE:
+		return v
+	case Compound:
+		return v
+	case WindowFunction:
+		return v
+	case WindowDefinition:
+		return v
+	case SubQueryField:
+		return v
+	case ExpressionField:
+		return v
+	case JSONExtractField:
+		return v
+	case MatchRankField:
+		return v
+	}
+
+	return nil
+}
+
+// withoutSelectClause returns joins without select fields and named windows carried by join queries.
+func withoutSelectClause(joins []rel.JoinQuery) []rel.JoinQuery {
+	result := make([]rel.JoinQuery, 0, len(joins))
+
+	for _, join := range joins {
+		switch queryClause(join).(type) {
+		case WindowFunction, WindowDefinition, SubQueryField, ExpressionField, JSONExtractField, MatchRankField:
+			continue
 		}
+
+		result = append(result, join)
 	}
+
+	return result
 }
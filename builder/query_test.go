@@ -241,6 +241,363 @@ func TestQuery_Build_SQLQuery(t *testing.T) {
 	assert.Equal(t, []any{1}, args)
 }
 
+func TestQuery_Build_with(t *testing.T) {
+	var (
+		queryBuilder = Query{
+			BufferFactory: BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}},
+			Filter:        Filter{},
+		}
+		active = With("active_users", rel.From("users").Where(where.Eq("active", true)))
+		tree   = WithRecursive("tree",
+			rel.Select("id", "parent_id").From("categories").Where(where.Eq("id", 1)),
+			rel.Select("c.id", "c.parent_id").From("categories as c").JoinOn("tree as t", "t.id", "c.parent_id"),
+		)
+		recursive = tree.Arguments[0].(CTE)
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		query  rel.Query
+	}{
+		{
+			result: "WITH `active_users` AS (SELECT `users`.* FROM `users` WHERE `users`.`active`=?) SELECT `active_users`.* FROM `active_users` WHERE `active_users`.`age`>?;",
+			args:   []any{true, 17},
+			query:  rel.Build("active_users", active, where.Gt("age", 17)),
+		},
+		{
+			result: "WITH RECURSIVE `tree` AS (SELECT `categories`.`id`,`categories`.`parent_id` FROM `categories` WHERE `categories`.`id`=? UNION ALL SELECT `c`.`id`,`c`.`parent_id` FROM `categories` AS `c` JOIN `tree` AS `t` ON `t`.`id`=`c`.`parent_id`) SELECT `tree`.* FROM `tree`;",
+			args:   []any{1},
+			query:  rel.Build("tree", tree),
+		},
+		{
+			result: "WITH RECURSIVE `active_users` AS (SELECT `users`.* FROM `users` WHERE `users`.`active`=?),`tree` (`id`,`parent_id`) AS (SELECT `categories`.`id`,`categories`.`parent_id` FROM `categories` WHERE `categories`.`id`=? UNION ALL SELECT `c`.`id`,`c`.`parent_id` FROM `categories` AS `c` JOIN `tree` AS `t` ON `t`.`id`=`c`.`parent_id`) SELECT `products`.* FROM `products` JOIN `tree` ON `tree`.`id`=`products`.`category_id` WHERE `products`.`user_id` IN (SELECT `active_users`.`id` FROM `active_users`);",
+			args:   []any{true, 1},
+			query: rel.Build("products",
+				active,
+				WithRecursive("tree", recursive.Query, recursive.RecursiveQuery, "id", "parent_id"),
+				rel.JoinOn("tree", "tree.id", "products.category_id"),
+				where.In("user_id", rel.Select("id").From("active_users")),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result, args := queryBuilder.Build(test.query)
+
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
+func TestQuery_Build_withOrdinal(t *testing.T) {
+	var (
+		queryBuilder = Query{
+			BufferFactory: BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}},
+			Filter:        Filter{},
+		}
+		query = rel.Build("tree",
+			WithRecursive("tree",
+				rel.Select("id", "parent_id").From("categories").Where(where.Eq("id", 1)),
+				rel.Select("c.id", "c.parent_id").From("categories as c").JoinOn("tree as t", "t.id", "c.parent_id").Where(where.Ne("c.hidden", true)),
+			),
+			rel.JoinOn("products", "products.category_id", "tree.id", where.Gt("products.price", 100)),
+			where.Lt("products.price", 1000),
+		)
+		result, args = queryBuilder.Build(query)
+	)
+
+	assert.Equal(t, "WITH RECURSIVE \"tree\" AS (SELECT \"categories\".\"id\",\"categories\".\"parent_id\" FROM \"categories\" WHERE \"categories\".\"id\"=$1 UNION ALL SELECT \"c\".\"id\",\"c\".\"parent_id\" FROM \"categories\" AS \"c\" JOIN \"tree\" AS \"t\" ON \"t\".\"id\"=\"c\".\"parent_id\" WHERE \"c\".\"hidden\"<>$2) SELECT \"tree\".* FROM \"tree\" JOIN \"products\" ON \"products\".\"category_id\"=\"tree\".\"id\" AND \"products\".\"price\">$3 WHERE \"products\".\"price\"<$4;", result)
+	assert.Equal(t, []any{1, true, 100, 1000}, args)
+}
+
+func TestQuery_Build_compound(t *testing.T) {
+	var (
+		queryBuilder = Query{
+			BufferFactory: BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}},
+			Filter:        Filter{},
+		}
+		users    = rel.Select("id", "name").From("users").Where(where.Like("name", "%foo%"))
+		products = rel.Select("id", "name").From("products").Where(where.Like("name", "%foo%"))
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		query  rel.Query
+	}{
+		{
+			result: "SELECT `users`.`id`,`users`.`name` FROM `users` WHERE `users`.`name` LIKE ? UNION SELECT `products`.`id`,`products`.`name` FROM `products` WHERE `products`.`name` LIKE ?;",
+			args:   []any{"%foo%", "%foo%"},
+			query:  rel.Build("", users, Union(products)),
+		},
+		{
+			result: "SELECT `users`.`id`,`users`.`name` FROM `users` WHERE `users`.`name` LIKE ? UNION ALL SELECT `products`.`id`,`products`.`name` FROM `products` WHERE `products`.`name` LIKE ? ORDER BY `name` ASC LIMIT 10 OFFSET 20;",
+			args:   []any{"%foo%", "%foo%"},
+			query:  rel.Build("", users, UnionAll(products), rel.SortAsc("name"), rel.Limit(10), rel.Offset(20)),
+		},
+		{
+			result: "SELECT `users`.`id` FROM `users` INTERSECT SELECT `admins`.`user_id` FROM `admins` EXCEPT SELECT `bans`.`user_id` FROM `bans` WHERE `bans`.`active`=?;",
+			args:   []any{true},
+			query: rel.Build("",
+				rel.Select("id").From("users"),
+				Intersect(rel.Select("user_id").From("admins")),
+				Except(rel.Select("user_id").From("bans").Where(where.Eq("active", true))),
+			),
+		},
+		{
+			result: "SELECT `users`.`id`,`users`.`name` FROM `users` WHERE `users`.`name` LIKE ? UNION (SELECT `products`.`id`,`products`.`name` FROM `products` WHERE `products`.`name` LIKE ? ORDER BY `products`.`id` DESC LIMIT 5) ORDER BY `id` ASC;",
+			args:   []any{"%foo%", "%foo%"},
+			query:  rel.Build("", users, Union(products.SortDesc("id").Limit(5)), rel.SortAsc("id")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result, args := queryBuilder.Build(test.query)
+
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
+func TestQuery_Build_compoundOrdinal(t *testing.T) {
+	var (
+		queryBuilder = Query{
+			BufferFactory: BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}},
+			Filter:        Filter{},
+		}
+		query = rel.Build("",
+			rel.Select("id", "title").From("posts").Where(where.Eq("published", true)),
+			UnionAll(rel.Select("id", "title").From("pages").Where(where.Eq("published", true), where.Gt("views", 10))),
+			UnionAll(rel.Select("id", "name").From("tags").Where(where.Like("name", "go%"))),
+			rel.SortDesc("id"),
+			rel.Limit(20),
+		)
+		result, args = queryBuilder.Build(query)
+	)
+
+	assert.Equal(t, "SELECT \"posts\".\"id\",\"posts\".\"title\" FROM \"posts\" WHERE \"posts\".\"published\"=$1 UNION ALL SELECT \"pages\".\"id\",\"pages\".\"title\" FROM \"pages\" WHERE (\"pages\".\"published\"=$2 AND \"pages\".\"views\">$3) UNION ALL SELECT \"tags\".\"id\",\"tags\".\"name\" FROM \"tags\" WHERE \"tags\".\"name\" LIKE $4 ORDER BY \"id\" DESC LIMIT 20;", result)
+	assert.Equal(t, []any{true, true, 10, "go%"}, args)
+}
+
+func TestQuery_Build_window(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, Filter: Filter{}}
+	)
+
+	tests := []struct {
+		result string
+		query  rel.Query
+		args   []any
+	}{
+		{
+			result: "SELECT `orders`.`id`,ROW_NUMBER() OVER (PARTITION BY `orders`.`user_id` ORDER BY `orders`.`created_at` ASC) AS `rn` FROM `orders`;",
+			query: rel.Build("orders", rel.Select("id"),
+				SelectOver("ROW_NUMBER", Window{PartitionBy: []string{"user_id"}, OrderBy: []rel.SortQuery{rel.SortAsc("created_at")}}, "rn"),
+			),
+		},
+		{
+			result: "SELECT `orders`.*,SUM(`orders`.`amount`) OVER (ORDER BY `orders`.`created_at` ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS `total` FROM `orders`;",
+			query: rel.Build("orders",
+				SelectOver("SUM", Window{OrderBy: []rel.SortQuery{rel.SortAsc("created_at")}, Frame: "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW"}, "total", "amount"),
+			),
+		},
+		{
+			result: "SELECT `orders`.`id`,RANK() OVER `w` AS `r`,SUM(`orders`.`amount`) OVER (`w` ROWS UNBOUNDED PRECEDING) AS `total` FROM `orders` WHERE `orders`.`amount`>? WINDOW `w` AS (PARTITION BY `orders`.`user_id`,`orders`.`status` ORDER BY `orders`.`amount` DESC) ORDER BY `orders`.`id` ASC;",
+			query: rel.Build("orders", rel.Select("id"),
+				SelectOver("RANK", Window{Name: "w"}, "r"),
+				SelectOver("SUM", Window{Name: "w", Frame: "ROWS UNBOUNDED PRECEDING"}, "total", "amount"),
+				DefineWindow("w", Window{PartitionBy: []string{"user_id", "status"}, OrderBy: []rel.SortQuery{rel.SortDesc("amount")}}),
+				rel.Where(where.Gt("amount", 10)),
+				rel.SortAsc("id"),
+			),
+			args: []any{10},
+		},
+		{
+			result: "SELECT `orders`.`id`,LAG(`orders`.`amount`,?) OVER (PARTITION BY `orders`.`user_id` ORDER BY `orders`.`created_at` ASC) AS `previous` FROM `orders`;",
+			query: rel.Build("orders", rel.Select("id"),
+				SelectOver("LAG", Window{PartitionBy: []string{"user_id"}, OrderBy: []rel.SortQuery{rel.SortAsc("created_at")}}, "previous", "amount", 1),
+			),
+			args: []any{1},
+		},
+		{
+			result: "SELECT `orders`.`id`,COUNT(DISTINCT `orders`.`user_id`) OVER (PARTITION BY `orders`.`status`) AS `users`,COUNT(*) OVER () AS `total` FROM `orders`;",
+			query: rel.Build("orders", rel.Select("id"),
+				SelectOverDistinct("COUNT", Window{PartitionBy: []string{"status"}}, "users", "user_id"),
+				SelectOver("COUNT", Window{}, "total", "*"),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result, args := queryBuilder.Build(test.query)
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
+func TestQuery_Build_sqlServerPagination(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "@p", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "[", IDSuffix: "]", IDSuffixEscapeChar: "]", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, Filter: Filter{}, Pagination: Top, FallbackOrderBy: "(SELECT NULL)"}
+	)
+
+	tests := []struct {
+		result  string
+		builder Query
+		query   rel.Query
+	}{
+		{
+			result:  "SELECT TOP 10 [users].* FROM [users];",
+			builder: queryBuilder,
+			query:   rel.From("users").Limit(10),
+		},
+		{
+			result:  "SELECT DISTINCT TOP 10 [users].[name] FROM [users] ORDER BY [users].[name] ASC;",
+			builder: queryBuilder,
+			query:   rel.Select("name").From("users").Distinct().SortAsc("name").Limit(10),
+		},
+		{
+			result:  "SELECT [users].* FROM [users] ORDER BY [users].[id] ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY;",
+			builder: queryBuilder,
+			query:   rel.From("users").SortAsc("id").Limit(10).Offset(20),
+		},
+		{
+			result:  "SELECT [users].* FROM [users] ORDER BY (SELECT NULL) OFFSET 20 ROWS;",
+			builder: queryBuilder,
+			query:   rel.From("users").Offset(20),
+		},
+		{
+			result:  "SELECT [users].* FROM [users] WHERE [users].[id]>@p1 ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY;",
+			builder: Query{BufferFactory: bufferFactory, Pagination: OffsetFetch, FallbackOrderBy: "(SELECT NULL)"},
+			query:   rel.From("users").Where(where.Gt("id", 1)).Limit(10),
+		},
+		{
+			result:  "SELECT [users].[id] FROM [users] UNION SELECT [admins].[id] FROM [admins] ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY;",
+			builder: queryBuilder,
+			query:   rel.Build("", rel.Select("id").From("users"), Union(rel.Select("id").From("admins")), rel.Limit(10)),
+		},
+		{
+			result:  "SELECT [users].* FROM [users];",
+			builder: queryBuilder,
+			query:   rel.From("users"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result, _ := test.builder.Build(test.query)
+			assert.Equal(t, test.result, result)
+		})
+	}
+}
+
+func TestQuery_Build_selectClause(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, Filter: Filter{}}
+		postCount     = rel.Select("COUNT(id)").From("posts").Where(where.Fragment("\"posts\".\"user_id\"=\"users\".\"id\""), where.Eq("published", true))
+		query         = rel.Build("users",
+			rel.Select("id", "name"),
+			SelectSubQuery(postCount, "post_count"),
+			SelectExpr("score", "points * ? + ?", 2, 10),
+			rel.Where(where.Eq("active", true)),
+		)
+		result, args = queryBuilder.Build(query)
+	)
+
+	assert.Equal(t, "SELECT \"users\".\"id\",\"users\".\"name\",(SELECT COUNT(\"posts\".\"id\") FROM \"posts\" WHERE (\"posts\".\"user_id\"=\"users\".\"id\" AND \"posts\".\"published\"=$1)) AS \"post_count\",points * $2 + $3 AS \"score\" FROM \"users\" WHERE \"users\".\"active\"=$4;", result)
+	assert.Equal(t, []any{true, 2, 10, true}, args)
+}
+
+func TestQuery_BuildAggregate(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		queryBuilder  = Query{BufferFactory: bufferFactory, Filter: Filter{}}
+	)
+
+	tests := []struct {
+		result string
+		args   []any
+		query  rel.Query
+	}{
+		{
+			result: "SELECT count(*) AS result FROM `orders` WHERE `orders`.`amount`>?;",
+			args:   []any{10},
+			query:  rel.Build("orders", rel.Select("id"), rel.Where(where.Gt("amount", 10))),
+		},
+		{
+			result: "SELECT count(*) AS result,`orders`.`status` FROM `orders` GROUP BY `orders`.`status`;",
+			query:  rel.From("orders").Group("status"),
+		},
+		{
+			result: "WITH `paid` AS (SELECT `orders`.* FROM `orders` WHERE `orders`.`paid`=?) SELECT count(*) AS result FROM `paid`;",
+			args:   []any{true},
+			query:  rel.Build("paid", With("paid", rel.From("orders").Where(where.Eq("paid", true)))),
+		},
+		{
+			result: "SELECT count(*) AS result FROM `orders` WHERE `orders`.`amount`>?;",
+			args:   []any{10},
+			query: rel.Build("orders", rel.Select("id"),
+				SelectOver("RANK", Window{Name: "w"}, "r"),
+				DefineWindow("w", Window{PartitionBy: []string{"user_id"}, OrderBy: []rel.SortQuery{rel.SortDesc("amount")}}),
+				rel.Where(where.Gt("amount", 10)),
+			),
+		},
+		{
+			result: "SELECT count(*) AS result FROM `users` WHERE `users`.`active`=?;",
+			args:   []any{true},
+			query: rel.Build("users",
+				SelectSubQuery(rel.Select("COUNT(id)").From("posts").Where(where.Eq("published", true)), "post_count"),
+				rel.Where(where.Eq("active", true)),
+			),
+		},
+		{
+			result: "SELECT count(*) AS result FROM `users` WHERE `users`.`active`=?;",
+			args:   []any{true},
+			query:  rel.Build("users", SelectExpr("score", "points * ? + ?", 2, 10), rel.Where(where.Eq("active", true))),
+		},
+		{
+			result: "SELECT count(*) AS result FROM `orders` WHERE `orders`.`status`=?;",
+			args:   []any{"paid"},
+			query:  rel.Build("orders", SelectOver("LAG", Window{OrderBy: []rel.SortQuery{rel.SortAsc("id")}}, "previous", "amount", 1), rel.Where(where.Eq("status", "paid"))),
+		},
+		{
+			result: "SELECT count(*) AS result FROM `users` WHERE `users`.`active`=?;",
+			args:   []any{true},
+			query:  rel.Build("users", SelectJSON(JSONField("settings", "theme"), "theme"), rel.Where(where.Eq("active", true))),
+		},
+		{
+			result: "SELECT count(*) AS result FROM `posts` WHERE to_tsvector('simple', `posts`.`title`) @@ plainto_tsquery('simple', ?);",
+			args:   []any{"fox"},
+			query:  rel.Build("posts", SelectRank(Match([]string{"title"}, "fox"), "rank"), rel.Where(Match([]string{"title"}, "fox"))),
+		},
+		{
+			result: "SELECT count(*) AS result FROM (SELECT `users`.`id` FROM `users` WHERE `users`.`active`=? UNION SELECT `admins`.`user_id` FROM `admins`) AS t;",
+			args:   []any{true},
+			query: rel.Build("",
+				rel.Select("id").From("users").Where(where.Eq("active", true)),
+				Union(rel.Select("user_id").From("admins")),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			result, args := queryBuilder.BuildAggregate(test.query, "count", "*")
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
 func TestQuery_WriteSelect(t *testing.T) {
 	var (
 		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
@@ -654,6 +1011,22 @@ func TestQuery_WriteOrderBy(t *testing.T) {
 		queryBuilder.WriteOrderBy(&buffer, "table", []rel.SortQuery{sort.Asc("name"), sort.Desc("table2.created_at")})
 		assert.Equal(t, " ORDER BY `table`.`name` ASC, `table2`.`created_at` DESC", buffer.String())
 	})
+
+	t.Run("nulls order", func(t *testing.T) {
+		buffer := bufferFactory.Create()
+		queryBuilder.WriteOrderBy(&buffer, "", []rel.SortQuery{NullsFirst(sort.Asc("name")), NullsLast(sort.Desc("created_at")), sort.Asc("id")})
+		assert.Equal(t, " ORDER BY `name` ASC NULLS FIRST, `created_at` DESC NULLS LAST, `id` ASC", buffer.String())
+	})
+
+	t.Run("emulated nulls order", func(t *testing.T) {
+		var (
+			buffer       = bufferFactory.Create()
+			queryBuilder = Query{BufferFactory: bufferFactory, EmulateNullsOrder: true}
+		)
+
+		queryBuilder.WriteOrderBy(&buffer, "table", []rel.SortQuery{NullsFirst(sort.Asc("name")), NullsLast(sort.Desc("created_at")), sort.Asc("id")})
+		assert.Equal(t, " ORDER BY CASE WHEN `table`.`name` IS NULL THEN 0 ELSE 1 END, `table`.`name` ASC, CASE WHEN `table`.`created_at` IS NULL THEN 1 ELSE 0 END, `table`.`created_at` DESC, `table`.`id` ASC", buffer.String())
+	})
 }
 
 func TestQuery_WriteLimitOffset(t *testing.T) {
@@ -673,4 +1046,48 @@ func TestQuery_WriteLimitOffset(t *testing.T) {
 		queryBuilder.WriteLimitOffset(&buffer, 10, 10)
 		assert.Equal(t, " LIMIT 10 OFFSET 10", buffer.String())
 	})
+
+	t.Run("offset", func(t *testing.T) {
+		buffer := bufferFactory.Create()
+		queryBuilder.WriteLimitOffset(&buffer, 0, 10)
+		assert.Equal(t, " OFFSET 10", buffer.String())
+	})
+
+	t.Run("offset query", func(t *testing.T) {
+		result, _ := queryBuilder.Build(rel.From("users").Offset(100))
+		assert.Equal(t, "SELECT `users`.* FROM `users` OFFSET 100;", result)
+	})
+}
+
+func TestQuery_WriteLimitOffset_dialect(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+	)
+
+	tests := []struct {
+		name    string
+		builder Query
+		limit   rel.Limit
+		offset  rel.Offset
+		result  string
+	}{
+		{name: "sqlite offset", builder: Query{UnboundedLimit: UnboundedLimitSQLite}, offset: 100, result: " LIMIT -1 OFFSET 100"},
+		{name: "sqlite limit", builder: Query{UnboundedLimit: UnboundedLimitSQLite}, limit: 10, result: " LIMIT 10"},
+		{name: "mysql offset", builder: Query{UnboundedLimit: UnboundedLimitMySQL}, offset: 100, result: " LIMIT 18446744073709551615 OFFSET 100"},
+		{name: "mysql limit and offset", builder: Query{UnboundedLimit: UnboundedLimitMySQL}, limit: 10, offset: 100, result: " LIMIT 10 OFFSET 100"},
+		{name: "sqlite none", builder: Query{UnboundedLimit: UnboundedLimitSQLite}, result: ""},
+		{name: "postgres offset", builder: Query{}, offset: 100, result: " OFFSET 100"},
+		{name: "offset fetch offset", builder: Query{Pagination: OffsetFetch}, offset: 100, result: " OFFSET 100 ROWS"},
+		{name: "offset fetch limit", builder: Query{Pagination: OffsetFetch}, limit: 10, result: " OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{name: "offset fetch limit and offset", builder: Query{Pagination: OffsetFetch}, limit: 10, offset: 100, result: " OFFSET 100 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{name: "offset fetch none", builder: Query{Pagination: OffsetFetch}, result: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := bufferFactory.Create()
+			test.builder.WriteLimitOffset(&buffer, test.limit, test.offset)
+			assert.Equal(t, test.result, buffer.String())
+		})
+	}
 }
@@ -0,0 +1,52 @@
+package builder
+
+// Returning defines how columns of rows affected by insert, update and delete are returned.
+type Returning int
+
+const (
+	// ReturningNone is the default, used when returning is not supported,
+	// the adapter selects the affected rows inside the same transaction instead.
+	ReturningNone Returning = iota
+	// ReturningClause writes RETURNING clause at the end of statement, for PostgreSQL and SQLite.
+	// MariaDB only supports it for INSERT and DELETE, so its Update builder must stay on ReturningNone.
+	ReturningClause
+	// ReturningOutput writes OUTPUT INSERTED.column or OUTPUT DELETED.column before VALUES or WHERE, for SQL Server.
+	ReturningOutput
+)
+
+// Supported returns true if the dialect can return columns from the statement itself.
+func (r Returning) Supported() bool {
+	return r != ReturningNone
+}
+
+// WriteClause writes RETURNING clause of columns to buffer.
+func (r Returning) WriteClause(buffer *Buffer, columns []string) {
+	if r != ReturningClause || len(columns) == 0 {
+		return
+	}
+
+	buffer.WriteString(" RETURNING ")
+	for i, col := range columns {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.WriteEscape(col)
+	}
+}
+
+// WriteOutput writes OUTPUT clause of columns to buffer, prefix is either INSERTED or DELETED.
+func (r Returning) WriteOutput(buffer *Buffer, prefix string, columns []string) {
+	if r != ReturningOutput || len(columns) == 0 {
+		return
+	}
+
+	buffer.WriteString(" OUTPUT ")
+	for i, col := range columns {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		buffer.WriteString(prefix)
+		buffer.WriteByte('.')
+		buffer.WriteEscape(col)
+	}
+}
@@ -0,0 +1,192 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsert_BuildReturning(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", IDSuffixEscapeChar: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		mutates       = map[string]rel.Mutate{
+			"name": rel.Set("name", "foo"),
+		}
+	)
+
+	tests := []struct {
+		result    string
+		returning Returning
+		supported bool
+		mutates   map[string]rel.Mutate
+	}{
+		{
+			result:    "INSERT INTO \"users\" (\"name\") VALUES (?) RETURNING \"id\",\"created_at\";",
+			returning: ReturningClause,
+			supported: true,
+			mutates:   mutates,
+		},
+		{
+			result:    "INSERT INTO \"users\" (\"name\") OUTPUT INSERTED.\"id\",INSERTED.\"created_at\" VALUES (?);",
+			returning: ReturningOutput,
+			supported: true,
+			mutates:   mutates,
+		},
+		{
+			result:    "INSERT INTO \"users\" OUTPUT INSERTED.\"id\",INSERTED.\"created_at\" DEFAULT VALUES;",
+			returning: ReturningOutput,
+			supported: true,
+			mutates:   map[string]rel.Mutate{},
+		},
+		{
+			result:    "INSERT INTO \"users\" (\"name\") VALUES (?);",
+			returning: ReturningNone,
+			mutates:   mutates,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			insertBuilder := Insert{BufferFactory: bufferFactory, InsertDefaultValues: true, Returning: test.returning}
+			result, args, supported := insertBuilder.BuildReturning("users", "id", test.mutates, rel.OnConflict{}, []string{"id", "created_at"})
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, test.supported, supported)
+			if len(test.mutates) > 0 {
+				assert.Equal(t, []any{"foo"}, args)
+			}
+		})
+	}
+}
+
+func TestInsertAll_BuildReturning(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "$", ArgumentOrdinal: true, Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}}
+		bulkMutates   = []map[string]rel.Mutate{
+			{"name": rel.Set("name", "foo")},
+			{"name": rel.Set("name", "boo")},
+		}
+	)
+
+	tests := []struct {
+		result    string
+		returning Returning
+		supported bool
+	}{
+		{
+			result:    "INSERT INTO \"users\" (\"name\") VALUES ($1),($2) RETURNING *;",
+			returning: ReturningClause,
+			supported: true,
+		},
+		{
+			result:    "INSERT INTO \"users\" (\"name\") OUTPUT INSERTED.* VALUES ($1),($2);",
+			returning: ReturningOutput,
+			supported: true,
+		},
+		{
+			result:    "INSERT INTO \"users\" (\"name\") VALUES ($1),($2);",
+			returning: ReturningNone,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			insertAllBuilder := InsertAll{BufferFactory: bufferFactory, Returning: test.returning}
+			result, args, supported := insertAllBuilder.BuildReturning("users", "id", []string{"name"}, bulkMutates, rel.OnConflict{}, []string{"*"})
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, []any{"foo", "boo"}, args)
+			assert.Equal(t, test.supported, supported)
+		})
+	}
+}
+
+func TestUpdate_BuildReturning(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}}
+		mutates       = map[string]rel.Mutate{
+			"name": rel.Set("name", "foo"),
+		}
+	)
+
+	tests := []struct {
+		result    string
+		returning Returning
+		supported bool
+	}{
+		{
+			result:    "UPDATE \"users\" SET \"name\"=? WHERE \"users\".\"id\"=? RETURNING \"updated_at\";",
+			returning: ReturningClause,
+			supported: true,
+		},
+		{
+			result:    "UPDATE \"users\" SET \"name\"=? OUTPUT INSERTED.\"updated_at\" WHERE \"users\".\"id\"=?;",
+			returning: ReturningOutput,
+			supported: true,
+		},
+		{
+			result:    "UPDATE \"users\" SET \"name\"=? WHERE \"users\".\"id\"=?;",
+			returning: ReturningNone,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			updateBuilder := Update{BufferFactory: bufferFactory, Query: Query{BufferFactory: bufferFactory}, Returning: test.returning}
+			result, args, supported := updateBuilder.BuildReturning("users", "id", mutates, where.Eq("id", 1), []string{"updated_at"})
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, []any{"foo", 1}, args)
+			assert.Equal(t, test.supported, supported)
+		})
+	}
+}
+
+func TestDelete_BuildReturning(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}}
+	)
+
+	tests := []struct {
+		result    string
+		returning Returning
+		supported bool
+	}{
+		{
+			result:    "DELETE FROM \"users\" WHERE \"users\".\"id\"=? RETURNING \"id\",\"name\";",
+			returning: ReturningClause,
+			supported: true,
+		},
+		{
+			result:    "DELETE FROM \"users\" OUTPUT DELETED.\"id\",DELETED.\"name\" WHERE \"users\".\"id\"=?;",
+			returning: ReturningOutput,
+			supported: true,
+		},
+		{
+			result:    "DELETE FROM \"users\" WHERE \"users\".\"id\"=?;",
+			returning: ReturningNone,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			deleteBuilder := Delete{BufferFactory: bufferFactory, Query: Query{BufferFactory: bufferFactory}, Returning: test.returning}
+			result, args, supported := deleteBuilder.BuildReturning("users", where.Eq("id", 1), []string{"id", "name"})
+			assert.Equal(t, test.result, result)
+			assert.Equal(t, []any{1}, args)
+			assert.Equal(t, test.supported, supported)
+		})
+	}
+}
+
+func TestReturning_zero(t *testing.T) {
+	var returning Returning
+
+	assert.Equal(t, ReturningNone, returning)
+	assert.False(t, returning.Supported())
+
+	// zero value of builder doesn't write returning, so that dialect opts in.
+	deleteBuilder := Delete{BufferFactory: BufferFactory{Quoter: Quote{IDPrefix: "\"", IDSuffix: "\""}}}
+	result, _, supported := deleteBuilder.BuildReturning("users", rel.FilterQuery{}, []string{"id"})
+	assert.Equal(t, "DELETE FROM \"users\";", result)
+	assert.False(t, supported)
+}
@@ -0,0 +1,38 @@
+package builder
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// SubQueryField is a select field that is computed using scalar sub query.
+type SubQueryField struct {
+	Query rel.Query
+	Alias string
+}
+
+// ExpressionField is a select field that is computed using expression with bound values.
+//
+// Each ? in the expression is replaced by placeholder of the corresponding value.
+type ExpressionField struct {
+	Expression string
+	Values     []any
+	Alias      string
+}
+
+// SelectSubQuery selects the result of scalar sub query as alias.
+func SelectSubQuery(query rel.Query, alias string) rel.JoinQuery {
+	return rel.JoinQuery{
+		Mode:      "SELECT",
+		Table:     alias,
+		Arguments: []any{SubQueryField{Query: query, Alias: alias}},
+	}
+}
+
+// SelectExpr selects the result of expression as alias.
+func SelectExpr(alias string, expression string, values ...any) rel.JoinQuery {
+	return rel.JoinQuery{
+		Mode:      "SELECT",
+		Table:     alias,
+		Arguments: []any{ExpressionField{Expression: expression, Values: values, Alias: alias}},
+	}
+}
@@ -0,0 +1,44 @@
+package builder
+
+import (
+	"github.com/go-rel/rel"
+)
+
+const (
+	sortNullsFirst = 2
+	sortNullsLast  = 3
+)
+
+// NullsFirst sorts NULL values before non-NULL values.
+//
+// rel.SortQuery only stores the direction in the sign of Sort, so the NULL order is stored in its magnitude.
+func NullsFirst(sort rel.SortQuery) rel.SortQuery {
+	return withNullsOrder(sort, sortNullsFirst)
+}
+
+// NullsLast sorts NULL values after non-NULL values.
+func NullsLast(sort rel.SortQuery) rel.SortQuery {
+	return withNullsOrder(sort, sortNullsLast)
+}
+
+func withNullsOrder(sort rel.SortQuery, order int) rel.SortQuery {
+	if sort.Asc() {
+		sort.Sort = order
+	} else {
+		sort.Sort = -order
+	}
+
+	return sort
+}
+
+// nullsOrder returns explicit NULL order of sort, zero when it's not specified.
+func nullsOrder(sort rel.SortQuery) int {
+	switch sort.Sort {
+	case sortNullsFirst, -sortNullsFirst:
+		return sortNullsFirst
+	case sortNullsLast, -sortNullsLast:
+		return sortNullsLast
+	}
+
+	return 0
+}
@@ -303,3 +303,51 @@ func TestTable_BuildWithColumnMapper(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_Build_array(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{Quoter: Quote{IDPrefix: "\"", IDSuffix: "\"", IDSuffixEscapeChar: "\"", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		table         = rel.Table{
+			Op:   rel.SchemaCreate,
+			Name: "posts",
+			Definitions: []rel.TableDefinition{
+				rel.Column{Name: "tags", Type: sql.Array(rel.Text)},
+				rel.Column{Name: "codes", Type: sql.Array(rel.String), Limit: 8},
+				rel.Column{Name: "scores", Type: sql.Array(rel.Decimal), Precision: 6, Scale: 2},
+			},
+		}
+	)
+
+	tests := []struct {
+		result       string
+		columnMapper ColumnMapper
+	}{
+		{
+			result:       "CREATE TABLE \"posts\" (\"tags\" TEXT[], \"codes\" VARCHAR(8)[], \"scores\" DECIMAL(6,2)[]);",
+			columnMapper: sql.ArrayColumnMapper(sql.ColumnMapper),
+		},
+		{
+			result:       "CREATE TABLE \"posts\" (\"tags\" TEXT, \"codes\" TEXT, \"scores\" TEXT);",
+			columnMapper: sql.ColumnMapper,
+		},
+		{
+			result:       "CREATE TABLE \"posts\" (\"tags\" JSON, \"codes\" JSON, \"scores\" JSON);",
+			columnMapper: sql.JSONColumnMapper(sql.ColumnMapper, "JSON"),
+		},
+		{
+			result:       "CREATE TABLE \"posts\" (\"tags\" TEXT[], \"codes\" VARCHAR(8)[], \"scores\" DECIMAL(6,2)[]);",
+			columnMapper: sql.ArrayColumnMapper(sql.JSONColumnMapper(sql.ColumnMapper, "JSONB")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			tableBuilder := Table{
+				BufferFactory:       bufferFactory,
+				ColumnMapper:        test.columnMapper,
+				ColumnOptionsMapper: sql.ColumnOptionsMapper,
+			}
+			assert.Equal(t, test.result, tableBuilder.Build(table))
+		})
+	}
+}
@@ -6,31 +6,81 @@ import (
 
 // Update builder.
 type Update struct {
-	BufferFactory BufferFactory
-	Query         QueryWriter
-	Filter        Filter
+	BufferFactory   BufferFactory
+	Query           QueryWriter
+	Filter          Filter
+	UnorderedFields bool
+	Returning       Returning
 }
 
 // Build SQL string and it arguments.
 func (u Update) Build(table string, primaryField string, mutates map[string]rel.Mutate, filter rel.FilterQuery) (string, []any) {
+	statement, args, _ := u.BuildReturning(table, primaryField, mutates, filter, nil)
+	return statement, args
+}
+
+// BuildReturning builds SQL string that returns columns of the updated rows, and its arguments.
+// It returns false when returning is not supported, in which case the query doesn't return any row.
+func (u Update) BuildReturning(table string, primaryField string, mutates map[string]rel.Mutate, filter rel.FilterQuery, returning []string) (string, []any, bool) {
 	buffer := u.BufferFactory.Create()
 
 	buffer.WriteString("UPDATE ")
 	buffer.WriteTable(table)
 	buffer.WriteString(" SET ")
 
-	i := 0
-	for field, mut := range mutates {
+	var (
+		i              = 0
+		jsonMutations  = groupJSONMutations(mutates)
+		arrayMutations = groupArrayMutations(mutates)
+	)
+
+	for _, field := range mutatesFields(mutates, u.UnorderedFields) {
 		if field == primaryField {
 			continue
 		}
 
+		// mutations that rel.Mutate can't express, such as JSON path and array operations,
+		// are carried as the only value of a fragment mutation, in the same way as filterClause.
+		if jm, ok := jsonMutation(mutates[field]); ok {
+			mutations, ok := jsonMutations[jm.Path.Field]
+			if !ok {
+				// already written together with other mutation of the same field.
+				continue
+			}
+
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			i++
+
+			u.Filter.WriteJSONMutations(&buffer, jm.Path.Field, mutations)
+			delete(jsonMutations, jm.Path.Field)
+			continue
+		}
+
+		if am, ok := arrayMutation(mutates[field]); ok {
+			mutations, ok := arrayMutations[am.Field]
+			if !ok {
+				// already written together with other mutation of the same field.
+				continue
+			}
+
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			i++
+
+			u.Filter.WriteArrayMutations(&buffer, am.Field, mutations)
+			delete(arrayMutations, am.Field)
+			continue
+		}
+
 		if i > 0 {
 			buffer.WriteByte(',')
 		}
 		i++
 
-		switch mut.Type {
+		switch mut := mutates[field]; mut.Type {
 		case rel.ChangeSetOp:
 			buffer.WriteEscape(field)
 			buffer.WriteByte('=')
@@ -47,12 +97,15 @@ func (u Update) Build(table string, primaryField string, mutates map[string]rel.
 		}
 	}
 
+	u.Returning.WriteOutput(&buffer, "INSERTED", returning)
+
 	if !filter.None() {
 		buffer.WriteString(" WHERE ")
 		u.Filter.Write(&buffer, table, filter, u.Query)
 	}
 
+	u.Returning.WriteClause(&buffer, returning)
 	buffer.WriteString(";")
 
-	return buffer.String(), buffer.Arguments()
+	return buffer.String(), buffer.Arguments(), u.Returning.Supported()
 }
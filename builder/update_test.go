@@ -27,12 +27,12 @@ func TestUpdate_Build(t *testing.T) {
 	)
 
 	qs, qargs := updateBuilder.Build("users", "id", mutates, where.And())
-	assert.Regexp(t, fmt.Sprint("UPDATE `users` SET `", `\w*`, "`=", `\?`, ",`", `\w*`, "`=", `\?`, ",`", `\w*`, "`=", `\?`, ";"), qs)
-	assert.ElementsMatch(t, []any{"foo", 10, true}, qargs)
+	assert.Equal(t, "UPDATE `users` SET `age`=?,`agree`=?,`name`=?;", qs)
+	assert.Equal(t, []any{10, true, "foo"}, qargs)
 
 	qs, qargs = updateBuilder.Build("users", "id", mutates, where.Eq("id", 1))
-	assert.Regexp(t, fmt.Sprint("UPDATE `users` SET `", `\w*`, "`=", `\?`, ",`", `\w*`, "`=", `\?`, ",`", `\w*`, "`=", `\?`, " WHERE `users`.`id`=", `\?`, ";"), qs)
-	assert.ElementsMatch(t, []any{"foo", 10, true, 1}, qargs)
+	assert.Equal(t, "UPDATE `users` SET `age`=?,`agree`=?,`name`=? WHERE `users`.`id`=?;", qs)
+	assert.Equal(t, []any{10, true, "foo", 1}, qargs)
 }
 
 func TestUpdate_Build_ordinal(t *testing.T) {
@@ -52,12 +52,34 @@ func TestUpdate_Build_ordinal(t *testing.T) {
 	)
 
 	qs, args := updateBuilder.Build("users", "id", mutates, where.And())
-	assert.Regexp(t, `UPDATE "users" SET "\w*"=\$1,"\w*"=\$2,"\w*"=\$3;`, qs)
-	assert.ElementsMatch(t, []any{"foo", 10, true}, args)
+	assert.Equal(t, `UPDATE "users" SET "age"=$1,"agree"=$2,"name"=$3;`, qs)
+	assert.Equal(t, []any{10, true, "foo"}, args)
 
 	qs, args = updateBuilder.Build("users", "id", mutates, where.Eq("id", 1))
-	assert.Regexp(t, `UPDATE "users" SET "\w*"=\$1,"\w*"=\$2,"\w*"=\$3 WHERE "users"."id"=\$4;`, qs)
-	assert.ElementsMatch(t, []any{"foo", 10, true, 1}, args)
+	assert.Equal(t, `UPDATE "users" SET "age"=$1,"agree"=$2,"name"=$3 WHERE "users"."id"=$4;`, qs)
+	assert.Equal(t, []any{10, true, "foo", 1}, args)
+}
+
+func TestUpdate_Build_unorderedFields(t *testing.T) {
+	var (
+		bufferFactory = BufferFactory{ArgumentPlaceholder: "?", Quoter: Quote{IDPrefix: "`", IDSuffix: "`", IDSuffixEscapeChar: "`", ValueQuote: "'", ValueQuoteEscapeChar: "'"}}
+		filter        = Filter{}
+		updateBuilder = Update{
+			BufferFactory:   bufferFactory,
+			Query:           Query{BufferFactory: bufferFactory, Filter: filter},
+			Filter:          filter,
+			UnorderedFields: true,
+		}
+		mutates = map[string]rel.Mutate{
+			"name":  rel.Set("name", "foo"),
+			"age":   rel.Set("age", 10),
+			"agree": rel.Set("agree", true),
+		}
+	)
+
+	qs, qargs := updateBuilder.Build("users", "id", mutates, where.Eq("id", 1))
+	assert.Regexp(t, fmt.Sprint("UPDATE `users` SET `", `\w*`, "`=", `\?`, ",`", `\w*`, "`=", `\?`, ",`", `\w*`, "`=", `\?`, " WHERE `users`.`id`=", `\?`, ";"), qs)
+	assert.ElementsMatch(t, []any{"foo", 10, true, 1}, qargs)
 }
 
 func TestUpdate_Build_incDecAndFragment(t *testing.T) {
@@ -0,0 +1,39 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/sql"
+)
+
+// ErrInvalidArgument is panicked when builder function is called with arguments that can't be written.
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// fail panics with error wrapped in sql.BuildError, which is recovered and returned as error by the adapter.
+// format must wrap ErrUnsupported, ErrUnresolvableJoin or ErrInvalidArgument using %w.
+func fail(format string, args ...any) {
+	panic(sql.BuildError{Err: fmt.Errorf(format, args...)})
+}
+
+// mutatesFields returns fields of mutates, sorted unless unordered is true.
+// Sorted fields produce the same statement for the same mutates, which is friendlier to statement cache.
+func mutatesFields(mutates map[string]rel.Mutate, unordered bool) []string {
+	var (
+		i      = 0
+		fields = make([]string, len(mutates))
+	)
+
+	for field := range mutates {
+		fields[i] = field
+		i++
+	}
+
+	if !unordered {
+		sort.Strings(fields)
+	}
+
+	return fields
+}
@@ -0,0 +1,63 @@
+package builder
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// Window defines window specification used by OVER and WINDOW clause.
+//
+// Name refers to a window defined using DefineWindow, other properties extend the referred window.
+// Frame is written as is, for example: ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW.
+type Window struct {
+	Name        string
+	PartitionBy []string
+	OrderBy     []rel.SortQuery
+	Frame       string
+}
+
+// WindowFunction is a select field that is computed using a window function.
+//
+// Function is the name of the function, such as ROW_NUMBER, SUM or LAG.
+// String arguments refer to columns and are escaped like regular select fields, other arguments are bound as values.
+type WindowFunction struct {
+	Function  string
+	Arguments []any
+	Distinct  bool
+	Window    Window
+	Alias     string
+}
+
+// WindowDefinition is a named window written in WINDOW clause.
+type WindowDefinition struct {
+	Name   string
+	Window Window
+}
+
+// SelectOver selects the result of window function called with arguments over given window as alias.
+// For example SelectOver("LAG", window, "previous", "amount", 1) selects LAG(amount, 1).
+func SelectOver(function string, window Window, alias string, arguments ...any) rel.JoinQuery {
+	return WindowFunction{Function: function, Arguments: arguments, Window: window, Alias: alias}.joinQuery()
+}
+
+// SelectOverDistinct selects the result of aggregate function over distinct values of arguments over given window as alias.
+// For example SelectOverDistinct("COUNT", window, "users", "user_id") selects COUNT(DISTINCT user_id).
+func SelectOverDistinct(function string, window Window, alias string, arguments ...any) rel.JoinQuery {
+	return WindowFunction{Function: function, Arguments: arguments, Distinct: true, Window: window, Alias: alias}.joinQuery()
+}
+
+func (wf WindowFunction) joinQuery() rel.JoinQuery {
+	return rel.JoinQuery{
+		Mode:      "SELECT",
+		Table:     wf.Alias,
+		Arguments: []any{wf},
+	}
+}
+
+// DefineWindow defines a named window that can be referred by SelectOver.
+func DefineWindow(name string, window Window) rel.JoinQuery {
+	return rel.JoinQuery{
+		Mode:      "WINDOW",
+		Table:     name,
+		Arguments: []any{WindowDefinition{Name: name, Window: window}},
+	}
+}
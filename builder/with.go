@@ -0,0 +1,43 @@
+package builder
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// CTE defines a common table expression, which is written in WITH clause and can be referenced by name from the query.
+type CTE struct {
+	Name           string
+	Columns        []string
+	Recursive      bool
+	Query          rel.Query
+	RecursiveQuery rel.Query
+}
+
+func (c CTE) joinQuery() rel.JoinQuery {
+	return rel.JoinQuery{
+		Mode:      "WITH",
+		Table:     c.Name,
+		Arguments: []any{c},
+	}
+}
+
+// With defines a named common table expression using given query.
+func With(name string, query rel.Query, columns ...string) rel.JoinQuery {
+	return CTE{
+		Name:    name,
+		Columns: columns,
+		Query:   query,
+	}.joinQuery()
+}
+
+// WithRecursive defines a named recursive common table expression.
+// The anchor query is combined with the recursive query, which is able to reference the expression by its name.
+func WithRecursive(name string, anchor rel.Query, recursive rel.Query, columns ...string) rel.JoinQuery {
+	return CTE{
+		Name:           name,
+		Columns:        columns,
+		Recursive:      true,
+		Query:          anchor,
+		RecursiveQuery: recursive,
+	}.joinQuery()
+}
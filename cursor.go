@@ -7,6 +7,7 @@ import (
 // Cursor used for retrieving result.
 type Cursor struct {
 	*sql.Rows
+	finish func(err error) error
 }
 
 // Fields returned in the result.
@@ -18,3 +19,20 @@ func (c *Cursor) Fields() ([]string, error) {
 func (c *Cursor) NopScanner() any {
 	return &sql.RawBytes{}
 }
+
+// Close rows, and finishes the work that waits for the rows to be read, such as emulated returning.
+func (c *Cursor) Close() error {
+	err := c.Rows.Close()
+
+	if c.finish != nil {
+		if err == nil {
+			err = c.Rows.Err()
+		}
+
+		finish := c.finish
+		c.finish = nil
+		err = finish(err)
+	}
+
+	return err
+}
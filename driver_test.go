@@ -0,0 +1,172 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testDriver records statements executed by the adapter, statement that contains any of fails is failed with err.
+type testDriver struct {
+	mutex sync.Mutex
+	log   []string
+	fails []string
+	err   error
+	rows  [][]driver.Value
+}
+
+func (d *testDriver) record(op string, statement string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.log = append(d.log, op+" "+statement)
+	for _, fail := range d.fails {
+		if strings.Contains(op+" "+statement, fail) {
+			if d.err != nil {
+				return d.err
+			}
+
+			return errors.New("failed: " + statement)
+		}
+	}
+
+	return nil
+}
+
+func (d *testDriver) Log() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]string(nil), d.log...)
+}
+
+func (d *testDriver) Open(name string) (driver.Conn, error) {
+	return &testConn{driver: d}, nil
+}
+
+type testConn struct {
+	driver *testDriver
+}
+
+func (c *testConn) Prepare(statement string) (driver.Stmt, error) {
+	if err := c.driver.record("prepare", statement); err != nil {
+		return nil, err
+	}
+
+	return &testStmt{conn: c, statement: statement}, nil
+}
+
+func (c *testConn) Close() error { return nil }
+
+func (c *testConn) Begin() (driver.Tx, error) {
+	return c, c.driver.record("begin", "")
+}
+
+// BeginTx records non default options of the transaction, such as "begin Serializable READ ONLY".
+func (c *testConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	var options []string
+	if opts.Isolation != driver.IsolationLevel(sql.LevelDefault) {
+		options = append(options, sql.IsolationLevel(opts.Isolation).String())
+	}
+
+	if opts.ReadOnly {
+		options = append(options, "READ ONLY")
+	}
+
+	return c, c.driver.record("begin", strings.Join(options, " "))
+}
+
+func (c *testConn) Commit() error {
+	return c.driver.record("commit", "")
+}
+
+func (c *testConn) Rollback() error {
+	return c.driver.record("rollback", "")
+}
+
+func (c *testConn) ExecContext(ctx context.Context, statement string, args []driver.NamedValue) (driver.Result, error) {
+	return testResult{}, c.driver.record("exec", statement)
+}
+
+func (c *testConn) QueryContext(ctx context.Context, statement string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.driver.record("query", statement); err != nil {
+		return nil, err
+	}
+
+	return &testRows{values: c.driver.rows}, nil
+}
+
+type testStmt struct {
+	conn      *testConn
+	statement string
+}
+
+func (s *testStmt) Close() error {
+	return s.conn.driver.record("close", s.statement)
+}
+
+func (s *testStmt) NumInput() int { return -1 }
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return testResult{}, s.conn.driver.record("exec", s.statement)
+}
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if err := s.conn.driver.record("query", s.statement); err != nil {
+		return nil, err
+	}
+
+	return &testRows{values: s.conn.driver.rows}, nil
+}
+
+type testResult struct{}
+
+func (testResult) LastInsertId() (int64, error) { return 1, nil }
+func (testResult) RowsAffected() (int64, error) { return 1, nil }
+
+type testRows struct {
+	values [][]driver.Value
+}
+
+func (r *testRows) Columns() []string { return []string{"id"} }
+func (r *testRows) Close() error      { return nil }
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+var (
+	testDriverMutex sync.Mutex
+	testDriverCount int
+)
+
+func openTestDB(t *testing.T) (*testDriver, *sql.DB) {
+	testDriverMutex.Lock()
+	testDriverCount++
+	name := "sqltest" + strconv.Itoa(testDriverCount)
+	testDriverMutex.Unlock()
+
+	d := &testDriver{}
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	return d, db
+}
@@ -4,12 +4,12 @@ go 1.25.0
 
 require (
 	github.com/go-rel/rel v0.42.0
+	github.com/jinzhu/inflection v1.0.0
 	github.com/stretchr/testify v1.12.0
 )
 
 require (
 	github.com/fsnotify/fsnotify v1.6.0 // indirect
-	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/nxadm/tail v1.4.8 // indirect
 	github.com/serenize/snaker v0.0.0-20201027110005-a7ad2135616e // indirect
 	golang.org/x/net v0.55.0 // indirect
@@ -0,0 +1,149 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type primaryKey struct{}
+
+// WithPrimary returns a context that forces read queries to use primary database.
+// It's useful to read your own writes without waiting for replication.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func primaryFromContext(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// connectionError returns true if err is caused by unavailable database rather than the query itself.
+func connectionError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.As(err, &netErr)
+}
+
+// ReplicaSelector picks a replica to be used by read query.
+type ReplicaSelector interface {
+	Select(replicas []*sql.DB) *sql.DB
+}
+
+// LatencyObserver can be implemented by ReplicaSelector to be notified of read query latency on each replica,
+// and of failed connection to replica, after which the query is retried on primary database.
+type LatencyObserver interface {
+	ObserveLatency(replica *sql.DB, latency time.Duration)
+	ObserveError(replica *sql.DB, err error)
+}
+
+type roundRobin struct {
+	next uint32
+}
+
+func (rr *roundRobin) Select(replicas []*sql.DB) *sql.DB {
+	n := atomic.AddUint32(&rr.next, 1) - 1
+	return replicas[int(n%uint32(len(replicas)))]
+}
+
+// RoundRobin selects replicas in turn.
+func RoundRobin() ReplicaSelector {
+	return &roundRobin{}
+}
+
+type random struct{}
+
+func (random) Select(replicas []*sql.DB) *sql.DB {
+	return replicas[rand.Intn(len(replicas))]
+}
+
+// Random selects replica randomly.
+func Random() ReplicaSelector {
+	return random{}
+}
+
+const (
+	// latencyErrorPenalty is the latency recorded for a failed read query.
+	latencyErrorPenalty = 10 * time.Second
+	// latencyHalfLife is the duration after which recorded latency is halved,
+	// so that slow or failed replica is probed again eventually.
+	latencyHalfLife = 30 * time.Second
+)
+
+type latencySample struct {
+	average  time.Duration
+	observed time.Time
+}
+
+// decayed returns average latency that is halved for each half life passed since it's observed.
+func (ls latencySample) decayed(now time.Time) time.Duration {
+	halves := now.Sub(ls.observed) / latencyHalfLife
+	if halves >= 63 {
+		return 0
+	}
+
+	return ls.average >> uint(halves)
+}
+
+type leastLatency struct {
+	mutex     sync.RWMutex
+	latencies map[*sql.DB]latencySample
+	now       func() time.Time
+}
+
+func (ll *leastLatency) Select(replicas []*sql.DB) *sql.DB {
+	ll.mutex.RLock()
+	defer ll.mutex.RUnlock()
+
+	var (
+		now      = ll.now()
+		selected = replicas[0]
+		lowest   = ll.latencies[selected].decayed(now)
+	)
+
+	// replicas without measurement has zero latency, so they are picked first.
+	for _, replica := range replicas[1:] {
+		if latency := ll.latencies[replica].decayed(now); latency < lowest {
+			selected = replica
+			lowest = latency
+		}
+	}
+
+	return selected
+}
+
+func (ll *leastLatency) ObserveLatency(replica *sql.DB, latency time.Duration) {
+	ll.mutex.Lock()
+	defer ll.mutex.Unlock()
+
+	now := ll.now()
+
+	// exponentially weighted moving average, so a single slow query doesn't exclude the replica.
+	if sample, ok := ll.latencies[replica]; ok {
+		latency = (sample.decayed(now)*4 + latency) / 5
+	}
+
+	ll.latencies[replica] = latencySample{average: latency, observed: now}
+}
+
+func (ll *leastLatency) ObserveError(replica *sql.DB, err error) {
+	ll.mutex.Lock()
+	defer ll.mutex.Unlock()
+
+	ll.latencies[replica] = latencySample{average: latencyErrorPenalty, observed: ll.now()}
+}
+
+// LeastLatency selects replica with the lowest average read query latency.
+// Failed replica is penalized, and recorded latencies decay over time so that every replica is probed again.
+func LeastLatency() ReplicaSelector {
+	return &leastLatency{
+		latencies: make(map[*sql.DB]latencySample),
+		now:       time.Now,
+	}
+}
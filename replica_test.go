@@ -0,0 +1,89 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeastLatency(t *testing.T) {
+	var (
+		now      = time.Now()
+		selector = LeastLatency().(*leastLatency)
+		r1, r2   = &sql.DB{}, &sql.DB{}
+		replicas = []*sql.DB{r1, r2}
+	)
+
+	selector.now = func() time.Time { return now }
+
+	selector.ObserveLatency(r1, 10*time.Millisecond)
+	assert.Same(t, r2, selector.Select(replicas), "unmeasured replica is probed first")
+
+	selector.ObserveLatency(r2, 20*time.Millisecond)
+	assert.Same(t, r1, selector.Select(replicas))
+
+	selector.ObserveError(r1, errors.New("connection refused"))
+	assert.Same(t, r2, selector.Select(replicas), "failed replica is penalized")
+
+	now = now.Add(10 * latencyHalfLife)
+	for i := 0; i < 20; i++ {
+		selector.ObserveLatency(r2, 20*time.Millisecond)
+	}
+	assert.Same(t, r1, selector.Select(replicas), "failed replica is probed again after its penalty decays")
+}
+
+func TestSQL_DoQueryRead_replicaFailure(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		primary, db  = openTestDB(t)
+		replica, rdb = openTestDB(t)
+		selector     = LeastLatency()
+		adapter      = &SQL{DB: db, Replicas: []*sql.DB{rdb}, ReplicaSelector: selector, ErrorMapper: func(err error) error { return err }}
+	)
+
+	replica.fails = []string{"query"}
+	replica.err = driver.ErrBadConn
+
+	rows, err := adapter.DoQueryRead(ctx, rel.From("users"), "SELECT 1", nil)
+	assert.Nil(t, err)
+	rows.Close()
+
+	assert.Contains(t, replica.Log(), "query SELECT 1")
+	assert.Equal(t, []string{"query SELECT 1"}, primary.Log())
+	assert.Equal(t, latencyErrorPenalty, selector.(*leastLatency).latencies[rdb].average)
+}
+
+func TestSQL_DoQueryRead_queryError(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		primary, db  = openTestDB(t)
+		replica, rdb = openTestDB(t)
+		selector     = LeastLatency()
+		adapter      = &SQL{DB: db, Replicas: []*sql.DB{rdb}, ReplicaSelector: selector, ErrorMapper: func(err error) error { return err }}
+	)
+
+	replica.fails = []string{"query"}
+
+	_, err := adapter.DoQueryRead(ctx, rel.From("users"), "SELECT syntax error", nil)
+	assert.EqualError(t, err, "failed: SELECT syntax error")
+
+	// query error doesn't make the replica unavailable.
+	assert.Equal(t, []string{"query SELECT syntax error"}, replica.Log())
+	assert.Empty(t, primary.Log())
+	assert.NotContains(t, selector.(*leastLatency).latencies, rdb)
+}
+
+func TestConnectionError(t *testing.T) {
+	assert.True(t, connectionError(driver.ErrBadConn))
+	assert.True(t, connectionError(fmt.Errorf("query: %w", sql.ErrConnDone)))
+	assert.True(t, connectionError(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	assert.False(t, connectionError(errors.New("syntax error")))
+}
@@ -0,0 +1,95 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-rel/rel"
+)
+
+// ErrSerializationFailure should be wrapped by ErrorMapper when a transaction is aborted
+// because of serialization failure or deadlock, so it can be retried by RetryPolicy.
+var ErrSerializationFailure = errors.New("serialization failure or deadlock")
+
+// RetryPolicy defines how a transaction is replayed when it fails with retryable error.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Retry is disabled when it's less than two.
+	MaxAttempts int
+	// Backoff returns the delay before the given attempt, no delay is used when it's nil.
+	Backoff func(attempt int) time.Duration
+	// Retryable classifies error returned by the transaction, the error is already mapped by ErrorMapper.
+	// Errors wrapping ErrSerializationFailure are retried when it's nil.
+	Retryable func(err error) bool
+}
+
+func (rp RetryPolicy) retryable(err error) bool {
+	if rp.Retryable != nil {
+		return rp.Retryable(err)
+	}
+
+	return errors.Is(err, ErrSerializationFailure)
+}
+
+// ExponentialBackoff returns backoff function which doubles the delay on every attempt, capped at max.
+func ExponentialBackoff(base time.Duration, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		delay := base
+		for i := 2; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+
+		if delay > max {
+			delay = max
+		}
+
+		return delay
+	}
+}
+
+// Retry calls fn and replays it according to RetryPolicy.
+// It never retries inside an active transaction, since only the outermost transaction can be replayed.
+func (s SQL) Retry(ctx context.Context, fn func() error) error {
+	err := fn()
+	if s.Tx != nil {
+		return err
+	}
+
+	for attempt := 2; attempt <= s.RetryPolicy.MaxAttempts && err != nil && s.RetryPolicy.retryable(err); attempt++ {
+		if s.RetryPolicy.Backoff != nil {
+			timer := time.NewTimer(s.RetryPolicy.Backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+
+		finish := s.Instrumenter.Observe(ctx, "adapter-retry", "retry transaction attempt "+strconv.Itoa(attempt))
+		err = fn()
+		finish(err)
+	}
+
+	return err
+}
+
+type retrier interface {
+	Retry(ctx context.Context, fn func() error) error
+}
+
+// Transaction runs fn inside repository transaction.
+// The whole transaction is replayed when it fails with retryable error according to adapter's RetryPolicy.
+func Transaction(ctx context.Context, repo rel.Repository, fn func(ctx context.Context) error) error {
+	transaction := func() error {
+		return repo.Transaction(ctx, fn)
+	}
+
+	if adapter, ok := repo.Adapter(ctx).(retrier); ok {
+		return adapter.Retry(ctx, transaction)
+	}
+
+	return transaction()
+}
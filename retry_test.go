@@ -0,0 +1,165 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQL_Retry(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		attempts = 0
+		events   []string
+		adapter  = &SQL{
+			RetryPolicy: RetryPolicy{MaxAttempts: 3},
+			Instrumenter: func(ctx context.Context, op string, message string, args ...any) func(err error) {
+				events = append(events, op+": "+message)
+				return func(err error) {}
+			},
+		}
+	)
+
+	err := adapter.Retry(ctx, func() error {
+		attempts++
+		return fmt.Errorf("deadlock detected: %w", ErrSerializationFailure)
+	})
+
+	assert.True(t, errors.Is(err, ErrSerializationFailure))
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []string{
+		"adapter-retry: retry transaction attempt 2",
+		"adapter-retry: retry transaction attempt 3",
+	}, events)
+}
+
+func TestSQL_Retry_succeed(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		attempts = 0
+		adapter  = &SQL{RetryPolicy: RetryPolicy{MaxAttempts: 5}}
+	)
+
+	err := adapter.Retry(ctx, func() error {
+		attempts++
+		if attempts < 2 {
+			return ErrSerializationFailure
+		}
+
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestSQL_Retry_notRetryable(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		attempts = 0
+		errTest  = errors.New("constraint violation")
+	)
+
+	tests := []struct {
+		name    string
+		adapter *SQL
+	}{
+		{name: "default", adapter: &SQL{RetryPolicy: RetryPolicy{MaxAttempts: 3}}},
+		{name: "retryable", adapter: &SQL{RetryPolicy: RetryPolicy{MaxAttempts: 3, Retryable: func(err error) bool { return !errors.Is(err, errTest) }}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts = 0
+
+			err := test.adapter.Retry(ctx, func() error {
+				attempts++
+				return errTest
+			})
+
+			assert.Equal(t, errTest, err)
+			assert.Equal(t, 1, attempts)
+		})
+	}
+}
+
+func TestSQL_Retry_transaction(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		attempts = 0
+		d, db    = openTestDB(t)
+		adapter  = &SQL{DB: db, RetryPolicy: RetryPolicy{MaxAttempts: 3}, ErrorMapper: func(err error) error { return err }}
+	)
+
+	tx, err := adapter.Begin(ctx)
+	assert.Nil(t, err)
+
+	// savepoint can't be replayed, only the outermost transaction is retried.
+	err = tx.(*SQL).Retry(ctx, func() error {
+		attempts++
+		return ErrSerializationFailure
+	})
+
+	assert.Equal(t, ErrSerializationFailure, err)
+	assert.Equal(t, 1, attempts)
+	assert.Nil(t, tx.Rollback(ctx))
+	assert.Equal(t, []string{"begin ", "rollback "}, d.Log())
+}
+
+func TestSQL_Retry_contextCanceled(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		attempts    = 0
+		adapter     = &SQL{RetryPolicy: RetryPolicy{MaxAttempts: 3, Backoff: func(attempt int) time.Duration { return time.Hour }}}
+	)
+
+	defer cancel()
+
+	err := adapter.Retry(ctx, func() error {
+		attempts++
+		cancel()
+		return ErrSerializationFailure
+	})
+
+	assert.Equal(t, ErrSerializationFailure, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+
+	assert.Equal(t, 10*time.Millisecond, backoff(2))
+	assert.Equal(t, 20*time.Millisecond, backoff(3))
+	assert.Equal(t, 40*time.Millisecond, backoff(4))
+	assert.Equal(t, 50*time.Millisecond, backoff(5))
+	assert.Equal(t, 50*time.Millisecond, backoff(100))
+	assert.Equal(t, 50*time.Millisecond, ExponentialBackoff(time.Second, 50*time.Millisecond)(2))
+}
+
+func TestTransaction(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		attempts = 0
+		d, db    = openTestDB(t)
+		adapter  = &SQL{DB: db, RetryPolicy: RetryPolicy{MaxAttempts: 3}, ErrorMapper: func(err error) error { return err }}
+		repo     = rel.New(adapter)
+	)
+
+	err := Transaction(ctx, repo, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return ErrSerializationFailure
+		}
+
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []string{"begin ", "rollback ", "begin ", "rollback ", "begin ", "commit "}, d.Log())
+}
@@ -0,0 +1,275 @@
+package sql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-rel/rel"
+)
+
+// ErrReturningUnsupported is returned when builder doesn't support returning columns.
+var ErrReturningUnsupported = errors.New("sql: builder does not support returning")
+
+// ErrReturningPrimaryField is returned when returning is emulated without primary field.
+var ErrReturningPrimaryField = errors.New("sql: returning requires primary field on dialect without returning")
+
+// InsertReturning inserts a record and returns the requested columns of the inserted row using cursor.
+//
+// On dialect without returning, the row is selected using its primary value inside the same transaction.
+// The transaction started for this purpose is committed when the cursor is closed.
+func (s SQL) InsertReturning(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict, returning []string) (rel.Cursor, error) {
+	builder, ok := s.InsertBuilder.(InsertReturningBuilder)
+	if !ok {
+		return nil, ErrReturningUnsupported
+	}
+
+	statement, args, supported, err := buildInsertReturning(builder, query, primaryField, mutates, onConflict, returning)
+	if err != nil {
+		return nil, err
+	}
+
+	if supported {
+		return s.queryCursor(ctx, statement, args)
+	}
+
+	if primaryField == "" {
+		return nil, ErrReturningPrimaryField
+	}
+
+	return s.emulateReturning(ctx, func(tx SQL) (rel.Query, error) {
+		id, _, err := tx.Exec(ctx, statement, args)
+		if err != nil {
+			return rel.Query{}, err
+		}
+
+		var value any = id
+		if mut, ok := mutates[primaryField]; ok {
+			value = mut.Value
+		}
+
+		return rel.From(query.Table).Select(returning...).Where(rel.Eq(primaryField, value)), nil
+	}, nil)
+}
+
+func buildInsertReturning(builder InsertReturningBuilder, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict, returning []string) (statement string, args []any, supported bool, err error) {
+	defer recoverBuild(&err)
+
+	statement, args, supported = builder.BuildReturning(query.Table, primaryField, mutates, onConflict, returning)
+	return
+}
+
+// InsertAllReturning inserts multiple records and returns the requested columns of the inserted rows using cursor.
+//
+// On dialect without returning, the rows are selected using their primary values inside the same transaction.
+// The transaction started for this purpose is committed when the cursor is closed.
+func (s SQL) InsertAllReturning(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict, returning []string) (rel.Cursor, error) {
+	builder, ok := s.InsertAllBuilder.(InsertAllReturningBuilder)
+	if !ok {
+		return nil, ErrReturningUnsupported
+	}
+
+	statement, args, supported, err := buildInsertAllReturning(builder, query, primaryField, fields, bulkMutates, onConflict, returning)
+	if err != nil {
+		return nil, err
+	}
+
+	if supported {
+		return s.queryCursor(ctx, statement, args)
+	}
+
+	if primaryField == "" {
+		return nil, ErrReturningPrimaryField
+	}
+
+	return s.emulateReturning(ctx, func(tx SQL) (rel.Query, error) {
+		id, _, err := tx.Exec(ctx, statement, args)
+		if err != nil {
+			return rel.Query{}, err
+		}
+
+		ids := tx.insertedIDs(id, primaryField, bulkMutates)
+		return rel.From(query.Table).Select(returning...).Where(rel.In(primaryField, ids...)).SortAsc(primaryField), nil
+	}, nil)
+}
+
+func buildInsertAllReturning(builder InsertAllReturningBuilder, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict, returning []string) (statement string, args []any, supported bool, err error) {
+	defer recoverBuild(&err)
+
+	statement, args, supported = builder.BuildReturning(query.Table, primaryField, fields, bulkMutates, onConflict, returning)
+	return
+}
+
+// UpdateReturning updates records and returns the requested columns of the updated rows using cursor.
+//
+// On dialect without returning, primary values of the matching rows are locked and selected before the update,
+// then the updated rows are selected inside the same transaction.
+// The transaction started for this purpose is committed when the cursor is closed.
+func (s SQL) UpdateReturning(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, returning []string) (rel.Cursor, error) {
+	builder, ok := s.UpdateBuilder.(UpdateReturningBuilder)
+	if !ok {
+		return nil, ErrReturningUnsupported
+	}
+
+	statement, args, supported, err := buildUpdateReturning(builder, query, primaryField, mutates, returning)
+	if err != nil {
+		return nil, err
+	}
+
+	if supported {
+		return s.queryCursor(ctx, statement, args)
+	}
+
+	if primaryField == "" {
+		return nil, ErrReturningPrimaryField
+	}
+
+	return s.emulateReturning(ctx, func(tx SQL) (rel.Query, error) {
+		ids, err := tx.selectIDs(ctx, query.Select(primaryField).Lock(string(rel.ForUpdate())))
+		if err != nil {
+			return rel.Query{}, err
+		}
+
+		if _, _, err := tx.Exec(ctx, statement, args); err != nil {
+			return rel.Query{}, err
+		}
+
+		return rel.From(query.Table).Select(returning...).Where(rel.In(primaryField, ids...)).SortAsc(primaryField), nil
+	}, nil)
+}
+
+func buildUpdateReturning(builder UpdateReturningBuilder, query rel.Query, primaryField string, mutates map[string]rel.Mutate, returning []string) (statement string, args []any, supported bool, err error) {
+	defer recoverBuild(&err)
+
+	statement, args, supported = builder.BuildReturning(query.Table, primaryField, mutates, query.WhereQuery, returning)
+	return
+}
+
+// DeleteReturning deletes records and returns the requested columns of the deleted rows using cursor.
+//
+// On dialect without returning, the matching rows are locked and selected first,
+// and they are deleted inside the same transaction when the cursor is closed.
+func (s SQL) DeleteReturning(ctx context.Context, query rel.Query, returning []string) (rel.Cursor, error) {
+	builder, ok := s.DeleteBuilder.(DeleteReturningBuilder)
+	if !ok {
+		return nil, ErrReturningUnsupported
+	}
+
+	statement, args, supported, err := buildDeleteReturning(builder, query, returning)
+	if err != nil {
+		return nil, err
+	}
+
+	if supported {
+		return s.queryCursor(ctx, statement, args)
+	}
+
+	return s.emulateReturning(ctx, func(tx SQL) (rel.Query, error) {
+		return rel.From(query.Table).Select(returning...).Where(query.WhereQuery).Lock(string(rel.ForUpdate())), nil
+	}, func(tx SQL) error {
+		_, _, err := tx.Exec(ctx, statement, args)
+		return err
+	})
+}
+
+func buildDeleteReturning(builder DeleteReturningBuilder, query rel.Query, returning []string) (statement string, args []any, supported bool, err error) {
+	defer recoverBuild(&err)
+
+	statement, args, supported = builder.BuildReturning(query.Table, query.WhereQuery, returning)
+	return
+}
+
+func (s SQL) queryCursor(ctx context.Context, statement string, args []any) (rel.Cursor, error) {
+	rows, err := s.DoQuery(ctx, statement, args)
+	if err != nil {
+		return nil, s.ErrorMapper(err)
+	}
+
+	return &Cursor{Rows: rows}, nil
+}
+
+// emulateReturning performs write and selects the returned rows inside transaction.
+// A new transaction is started when there's no active transaction, and finished when the cursor is closed.
+// deferred is performed after the rows are read, before the transaction is committed.
+func (s SQL) emulateReturning(ctx context.Context, write func(tx SQL) (rel.Query, error), deferred func(tx SQL) error) (rel.Cursor, error) {
+	var (
+		tx    = s
+		owned = s.Tx == nil
+	)
+
+	if owned {
+		adapter, err := s.Begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		tx = *adapter.(*SQL)
+	}
+
+	finish := func(err error) error {
+		if err == nil && deferred != nil {
+			err = deferred(tx)
+		}
+
+		if !owned {
+			return err
+		}
+
+		if err != nil {
+			tx.Rollback(ctx)
+			return err
+		}
+
+		return tx.Commit(ctx)
+	}
+
+	query, err := write(tx)
+	if err != nil {
+		if owned {
+			tx.Rollback(ctx)
+		}
+		return nil, err
+	}
+
+	statement, args, err := tx.buildQuery(query)
+	if err != nil {
+		return nil, finish(err)
+	}
+
+	rows, err := tx.DoQuery(ctx, statement, args)
+	if err != nil {
+		return nil, finish(tx.ErrorMapper(err))
+	}
+
+	return &Cursor{Rows: rows, finish: finish}, nil
+}
+
+// selectIDs selects values of the first column of query.
+func (s SQL) selectIDs(ctx context.Context, query rel.Query) ([]any, error) {
+	statement, args, err := s.buildQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.DoQuery(ctx, statement, args)
+	if err != nil {
+		return nil, s.ErrorMapper(err)
+	}
+
+	defer rows.Close()
+
+	var ids []any
+	for rows.Next() {
+		var id any
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		if b, ok := id.([]byte); ok {
+			id = string(b)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, s.ErrorMapper(rows.Err())
+}
@@ -0,0 +1,212 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+// returningQueryBuilder records queries built by the adapter.
+type returningQueryBuilder struct {
+	queries *[]rel.Query
+}
+
+func (qb returningQueryBuilder) Build(query rel.Query) (string, []any) {
+	*qb.queries = append(*qb.queries, query)
+	if query.LockQuery != "" {
+		return "SELECT " + query.Table + " " + string(query.LockQuery), nil
+	}
+
+	return "SELECT " + query.Table, nil
+}
+
+// unsupportedReturning builds statements for dialect without returning.
+type unsupportedReturning struct{}
+
+func (unsupportedReturning) Build(table string, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (string, []any) {
+	return "INSERT " + table, nil
+}
+
+func (ur unsupportedReturning) BuildReturning(table string, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict, returning []string) (string, []any, bool) {
+	statement, args := ur.Build(table, primaryField, mutates, onConflict)
+	return statement, args, false
+}
+
+type unsupportedUpdateReturning struct{}
+
+func (unsupportedUpdateReturning) Build(table string, primaryField string, mutates map[string]rel.Mutate, filter rel.FilterQuery) (string, []any) {
+	return "UPDATE " + table, nil
+}
+
+func (ur unsupportedUpdateReturning) BuildReturning(table string, primaryField string, mutates map[string]rel.Mutate, filter rel.FilterQuery, returning []string) (string, []any, bool) {
+	statement, args := ur.Build(table, primaryField, mutates, filter)
+	return statement, args, false
+}
+
+type unsupportedDeleteReturning struct{}
+
+func (unsupportedDeleteReturning) Build(table string, filter rel.FilterQuery) (string, []any) {
+	return "DELETE " + table, nil
+}
+
+func (dr unsupportedDeleteReturning) BuildReturning(table string, filter rel.FilterQuery, returning []string) (string, []any, bool) {
+	statement, args := dr.Build(table, filter)
+	return statement, args, false
+}
+
+func openReturningAdapter(t *testing.T) (*testDriver, *SQL, *[]rel.Query) {
+	var (
+		d, db   = openTestDB(t)
+		queries []rel.Query
+	)
+
+	return d, &SQL{
+		DB:            db,
+		QueryBuilder:  returningQueryBuilder{queries: &queries},
+		InsertBuilder: unsupportedReturning{},
+		UpdateBuilder: unsupportedUpdateReturning{},
+		DeleteBuilder: unsupportedDeleteReturning{},
+		ErrorMapper:   func(err error) error { return err },
+	}, &queries
+}
+
+func readIDs(t *testing.T, cursor rel.Cursor) []int64 {
+	var ids []int64
+	for cursor.Next() {
+		var id int64
+		assert.Nil(t, cursor.Scan(&id))
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func TestSQL_InsertReturning_emulated(t *testing.T) {
+	var (
+		ctx                 = context.Background()
+		d, adapter, queries = openReturningAdapter(t)
+	)
+
+	d.rows = [][]driver.Value{{int64(1)}}
+
+	cursor, err := adapter.InsertReturning(ctx, rel.From("users"), "id", map[string]rel.Mutate{"name": rel.Set("name", "foo")}, rel.OnConflict{}, []string{"id", "name"})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1}, readIDs(t, cursor))
+	assert.Equal(t, []string{"begin ", "exec INSERT users", "query SELECT users"}, d.Log())
+
+	assert.Nil(t, cursor.Close())
+	assert.Equal(t, []string{"begin ", "exec INSERT users", "query SELECT users", "commit "}, d.Log())
+	assert.Equal(t, []rel.Query{
+		rel.From("users").Select("id", "name").Where(rel.Eq("id", int64(1))),
+	}, *queries)
+}
+
+func TestSQL_InsertReturning_emulatedWithoutPrimaryField(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		d, db   = openTestDB(t)
+		adapter = &SQL{DB: db, InsertBuilder: unsupportedReturning{}, ErrorMapper: func(err error) error { return err }}
+	)
+
+	_, err := adapter.InsertReturning(ctx, rel.From("users"), "", nil, rel.OnConflict{}, []string{"name"})
+	assert.Equal(t, ErrReturningPrimaryField, err)
+	assert.Empty(t, d.Log())
+}
+
+func TestSQL_InsertReturning_emulatedError(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		d, adapter, _ = openReturningAdapter(t)
+	)
+
+	d.fails = []string{"exec INSERT"}
+
+	_, err := adapter.InsertReturning(ctx, rel.From("users"), "id", nil, rel.OnConflict{}, []string{"id"})
+	assert.EqualError(t, err, "failed: INSERT users")
+	assert.Equal(t, []string{"begin ", "exec INSERT users", "rollback "}, d.Log())
+}
+
+func TestSQL_UpdateReturning_emulated(t *testing.T) {
+	var (
+		ctx                 = context.Background()
+		d, adapter, queries = openReturningAdapter(t)
+		query               = rel.From("users").Where(rel.Eq("active", true))
+	)
+
+	d.rows = [][]driver.Value{{int64(1)}, {int64(2)}}
+
+	cursor, err := adapter.UpdateReturning(ctx, query, "id", map[string]rel.Mutate{"name": rel.Set("name", "foo")}, []string{"id", "name"})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1, 2}, readIDs(t, cursor))
+
+	assert.Nil(t, cursor.Close())
+	assert.Equal(t, []string{"begin ", "query SELECT users FOR UPDATE", "exec UPDATE users", "query SELECT users", "commit "}, d.Log())
+	assert.Equal(t, []rel.Query{
+		query.Select("id").Lock("FOR UPDATE"),
+		rel.From("users").Select("id", "name").Where(rel.In("id", int64(1), int64(2))).SortAsc("id"),
+	}, *queries)
+}
+
+func TestSQL_UpdateReturning_emulatedInTransaction(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		d, adapter, _ = openReturningAdapter(t)
+	)
+
+	d.rows = [][]driver.Value{{int64(1)}}
+
+	tx, err := adapter.Begin(ctx)
+	assert.Nil(t, err)
+
+	cursor, err := tx.(*SQL).UpdateReturning(ctx, rel.From("users"), "id", nil, []string{"id"})
+	assert.Nil(t, err)
+	assert.Nil(t, cursor.Close())
+
+	// transaction that isn't started by the adapter is left to the caller.
+	assert.Equal(t, []string{"begin ", "query SELECT users FOR UPDATE", "exec UPDATE users", "query SELECT users"}, d.Log())
+	assert.Nil(t, tx.Commit(ctx))
+}
+
+func TestSQL_DeleteReturning_emulated(t *testing.T) {
+	var (
+		ctx                 = context.Background()
+		d, adapter, queries = openReturningAdapter(t)
+		query               = rel.From("users").Where(rel.Eq("active", false))
+	)
+
+	d.rows = [][]driver.Value{{int64(1)}}
+
+	cursor, err := adapter.DeleteReturning(ctx, query, []string{"id"})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1}, readIDs(t, cursor))
+
+	// rows are deleted after they're read.
+	assert.Equal(t, []string{"begin ", "query SELECT users FOR UPDATE"}, d.Log())
+
+	assert.Nil(t, cursor.Close())
+	assert.Equal(t, []string{"begin ", "query SELECT users FOR UPDATE", "exec DELETE users", "commit "}, d.Log())
+	assert.Equal(t, []rel.Query{
+		rel.From("users").Select("id").Where(query.WhereQuery).Lock("FOR UPDATE"),
+	}, *queries)
+}
+
+func TestSQL_DeleteReturning_emulatedError(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		d, adapter, _ = openReturningAdapter(t)
+	)
+
+	d.fails = []string{"exec DELETE"}
+
+	cursor, err := adapter.DeleteReturning(ctx, rel.From("users"), []string{"id"})
+	assert.Nil(t, err)
+
+	err = cursor.Close()
+	assert.EqualError(t, err, "failed: DELETE users")
+	// finish is performed only once.
+	assert.Nil(t, cursor.Close())
+	assert.Equal(t, []string{"begin ", "query SELECT users FOR UPDATE", "exec DELETE users", "rollback "}, d.Log())
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/go-rel/rel"
 	"github.com/go-rel/rel/sort"
 	"github.com/go-rel/rel/where"
+	"github.com/go-rel/sql/builder"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -128,6 +129,39 @@ func QueryWhereSubQuery(t *testing.T, repo rel.Repository, flags ...Flag) {
 	run(t, repo, tests)
 }
 
+// QueryTuple tests row value comparison specifications using composite primaries.
+func QueryTuple(t *testing.T, repo rel.Repository) {
+	var (
+		keys       = []string{"primary1", "primary2"}
+		composites = []Composite{
+			{Primary1: 10, Primary2: 1, Data: "tuple-10-1"},
+			{Primary1: 10, Primary2: 2, Data: "tuple-10-2"},
+			{Primary1: 11, Primary2: 1, Data: "tuple-11-1"},
+		}
+	)
+
+	repo.MustInsertAll(ctx, &composites)
+	waitForReplication()
+
+	t.Run("TupleIn", func(t *testing.T) {
+		var result []Composite
+		assert.Nil(t, repo.FindAll(ctx, &result, builder.TupleIn(keys, []any{10, 2}, []any{11, 1}), rel.SortAsc("primary1")))
+		assert.Equal(t, composites[1:], result)
+	})
+
+	t.Run("TupleGt", func(t *testing.T) {
+		var result []Composite
+		assert.Nil(t, repo.FindAll(ctx, &result, builder.TupleGt(keys, 10, 1), where.Lte("primary1", 11), rel.SortAsc("primary1"), rel.SortAsc("primary2")))
+		assert.Equal(t, composites[1:], result)
+	})
+
+	t.Run("Between", func(t *testing.T) {
+		var result []Composite
+		assert.Nil(t, repo.FindAll(ctx, &result, builder.Between("primary1", 10, 10), rel.SortAsc("primary2")))
+		assert.Equal(t, composites[:2], result)
+	})
+}
+
 // QueryNotFound tests query specifications when no result found.
 func QueryNotFound(t *testing.T, repo rel.Repository) {
 	t.Run("NotFound", func(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"strconv"
+	"time"
 
 	"github.com/go-rel/rel"
 )
@@ -15,6 +16,19 @@ type ErrorMapper func(error) error
 // IncrementFunc function.
 type IncrementFunc func(SQL) int
 
+type txOptionsKey struct{}
+
+// WithTxOptions returns a context that carries transaction options.
+// Transaction started by Begin using the returned context will use the given options.
+func WithTxOptions(ctx context.Context, opts *sql.TxOptions) context.Context {
+	return context.WithValue(ctx, txOptionsKey{}, opts)
+}
+
+func txOptionsFromContext(ctx context.Context) *sql.TxOptions {
+	opts, _ := ctx.Value(txOptionsKey{}).(*sql.TxOptions)
+	return opts
+}
+
 // SQL base adapter.
 type SQL struct {
 	QueryBuilder     QueryBuilder
@@ -27,8 +41,13 @@ type SQL struct {
 	Increment        int
 	IncrementFunc    IncrementFunc
 	ErrorMapper      ErrorMapper
+	RetryPolicy      RetryPolicy
+	StmtCache        *StmtCache
 	DB               *sql.DB
+	Replicas         []*sql.DB
+	ReplicaSelector  ReplicaSelector
 	Tx               *sql.Tx
+	TxOptions        *sql.TxOptions
 	Savepoint        int
 	Instrumenter     rel.Instrumenter
 }
@@ -46,43 +65,146 @@ func (s *SQL) Instrumentation(instrumenter rel.Instrumenter) {
 // DoExec using active database connection.
 func (s SQL) DoExec(ctx context.Context, statement string, args []any) (sql.Result, error) {
 	var (
-		err    error
-		result sql.Result
-		finish = s.Instrumenter.Observe(ctx, "adapter-exec", statement, args...)
+		err           error
+		result        sql.Result
+		stmt, release = s.prepare(ctx, s.DB, statement)
 	)
 
-	if s.Tx != nil {
+	finish := s.Instrumenter.Observe(ctx, "adapter-exec", statement, args...)
+	if stmt != nil {
+		result, err = stmt.ExecContext(ctx, args...)
+		if s.Tx != nil {
+			stmt.Close()
+		}
+		release()
+	} else if s.Tx != nil {
 		result, err = s.Tx.ExecContext(ctx, statement, args...)
 	} else {
 		result, err = s.DB.ExecContext(ctx, statement, args...)
 	}
-
 	finish(err)
+
 	return result, err
 }
 
 // DoQuery using active database connection.
 func (s SQL) DoQuery(ctx context.Context, statement string, args []any) (*sql.Rows, error) {
+	return s.doQuery(ctx, s.DB, statement, args)
+}
+
+// DoQueryRead performs read query using a replica when it's safe to do so.
+//
+// Primary database is used inside transaction, for locking query, query with UsePrimary
+// and context returned by WithPrimary. Query that fails to connect to replica is retried on primary database,
+// other error such as syntax or constraint error is returned as is.
+func (s SQL) DoQueryRead(ctx context.Context, query rel.Query, statement string, args []any) (*sql.Rows, error) {
+	if s.Tx != nil || len(s.Replicas) == 0 || query.UsePrimaryDb || query.LockQuery != "" || primaryFromContext(ctx) {
+		return s.DoQuery(ctx, statement, args)
+	}
+
+	var (
+		selector = s.ReplicaSelector
+		start    = time.Now()
+	)
+
+	if selector == nil {
+		selector = Random()
+	}
+
+	replica := selector.Select(s.Replicas)
+	rows, err := s.doQuery(ctx, replica, statement, args)
+
+	if err != nil && (ctx.Err() != nil || !connectionError(err)) {
+		return rows, err
+	}
+
+	if observer, ok := selector.(LatencyObserver); ok {
+		if err == nil {
+			observer.ObserveLatency(replica, time.Since(start))
+		} else {
+			observer.ObserveError(replica, err)
+		}
+	}
+
+	// replica is unavailable, retry using primary database.
+	if err != nil {
+		return s.DoQuery(ctx, statement, args)
+	}
+
+	return rows, nil
+}
+
+func (s SQL) doQuery(ctx context.Context, db *sql.DB, statement string, args []any) (*sql.Rows, error) {
 	var (
-		err  error
-		rows *sql.Rows
+		err           error
+		rows          *sql.Rows
+		stmt, release = s.prepare(ctx, db, statement)
 	)
 
 	finish := s.Instrumenter.Observe(ctx, "adapter-query", statement, args...)
-	if s.Tx != nil {
+	if stmt != nil {
+		// rows remain usable after the statement is released, closing of evicted statement is deferred by database/sql.
+		rows, err = stmt.QueryContext(ctx, args...)
+		release()
+	} else if s.Tx != nil {
 		rows, err = s.Tx.QueryContext(ctx, statement, args...)
 	} else {
-		rows, err = s.DB.QueryContext(ctx, statement, args...)
+		rows, err = db.QueryContext(ctx, statement, args...)
 	}
 	finish(err)
 
 	return rows, err
 }
 
+// prepare statement on db using statement cache, the statement is bound to active transaction if any.
+// The returned function releases the statement back to the cache after it's used.
+// It returns nil when statement cache is disabled, the statement isn't cacheable or it fails to be prepared,
+// in which case the statement is executed without preparation.
+// Statement that isn't cached is not prepared inside transaction, since preparing it on db requires another connection
+// while the transaction holds one, which never completes when the pool only has a single connection.
+func (s SQL) prepare(ctx context.Context, db *sql.DB, statement string) (*sql.Stmt, func()) {
+	if s.StmtCache == nil || !cacheableStatement(statement) {
+		return nil, nil
+	}
+
+	stmt, release := s.StmtCache.Get(db, statement)
+	if stmt != nil {
+		s.Instrumenter.Observe(ctx, "adapter-stmt-cache-hit", statement)(nil)
+	} else if s.Tx != nil {
+		return nil, nil
+	} else {
+		finish := s.Instrumenter.Observe(ctx, "adapter-stmt-cache-miss", statement)
+		prepared, err := db.PrepareContext(ctx, statement)
+		finish(err)
+
+		if err != nil {
+			return nil, nil
+		}
+
+		stmt, release = s.StmtCache.Put(db, statement, prepared)
+	}
+
+	if s.Tx != nil {
+		stmt = s.Tx.StmtContext(ctx, stmt)
+	}
+
+	return stmt, release
+}
+
 // Begin begins a new transaction.
+//
+// Transaction options can be passed using context returned by WithTxOptions.
 func (s SQL) Begin(ctx context.Context) (rel.Adapter, error) {
+	return s.BeginTx(ctx, txOptionsFromContext(ctx))
+}
+
+// BeginTx begins a new transaction using given options.
+//
+// Nested transaction is started as a savepoint, which can't change the options of active transaction.
+func (s SQL) BeginTx(ctx context.Context, opts *sql.TxOptions) (rel.Adapter, error) {
 	var (
 		tx        *sql.Tx
+		txOptions = opts
 		savepoint int
 		err       error
 	)
@@ -91,10 +213,16 @@ func (s SQL) Begin(ctx context.Context) (rel.Adapter, error) {
 
 	if s.Tx != nil {
 		tx = s.Tx
+		txOptions = s.TxOptions
 		savepoint = s.Savepoint + 1
-		_, err = s.Tx.ExecContext(ctx, "SAVEPOINT s"+strconv.Itoa(savepoint)+";")
+
+		if conflictTxOptions(s.TxOptions, opts) {
+			err = errors.New("unable to change isolation level or read-only mode inside a savepoint")
+		} else {
+			_, err = s.Tx.ExecContext(ctx, "SAVEPOINT s"+strconv.Itoa(savepoint)+";")
+		}
 	} else {
-		tx, err = s.DB.BeginTx(ctx, nil)
+		tx, err = s.DB.BeginTx(ctx, opts)
 	}
 
 	finish(err)
@@ -110,12 +238,30 @@ func (s SQL) Begin(ctx context.Context) (rel.Adapter, error) {
 		Increment:        s.Increment,
 		IncrementFunc:    s.IncrementFunc,
 		ErrorMapper:      s.ErrorMapper,
+		RetryPolicy:      s.RetryPolicy,
+		StmtCache:        s.StmtCache,
+		DB:               s.DB,
 		Tx:               tx,
+		TxOptions:        txOptions,
 		Savepoint:        savepoint,
 		Instrumenter:     s.Instrumenter,
 	}, s.ErrorMapper(err)
 }
 
+// conflictTxOptions returns true if options requested for a savepoint differ from the active transaction.
+func conflictTxOptions(active *sql.TxOptions, opts *sql.TxOptions) bool {
+	if opts == nil {
+		return false
+	}
+
+	if active == nil {
+		active = &sql.TxOptions{}
+	}
+
+	return (opts.Isolation != sql.LevelDefault && opts.Isolation != active.Isolation) ||
+		(opts.ReadOnly && !active.ReadOnly)
+}
+
 // Commit commits current transaction.
 func (s SQL) Commit(ctx context.Context) error {
 	var err error
@@ -154,28 +300,97 @@ func (s SQL) Rollback(ctx context.Context) error {
 	return s.ErrorMapper(err)
 }
 
-// Ping database.
+// Ping primary and replica databases.
 func (s SQL) Ping(ctx context.Context) error {
-	return s.DB.PingContext(ctx)
+	if err := s.DB.PingContext(ctx); err != nil {
+		return err
+	}
+
+	for _, replica := range s.Replicas {
+		if err := replica.PingContext(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// Close database connection.
+// Close database connections and cached statements.
 //
 // TODO: add closer to adapter interface
 func (s SQL) Close() error {
+	if s.StmtCache != nil {
+		s.StmtCache.Close()
+	}
+
+	for _, replica := range s.Replicas {
+		replica.Close()
+	}
+
 	return s.DB.Close()
 }
 
 // Query performs query operation.
 func (s SQL) Query(ctx context.Context, query rel.Query) (rel.Cursor, error) {
-	var (
-		statement, args = s.QueryBuilder.Build(query)
-		rows, err       = s.DoQuery(ctx, statement, args)
-	)
+	statement, args, err := s.buildQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.DoQueryRead(ctx, query, statement, args)
 
 	return &Cursor{Rows: rows}, s.ErrorMapper(err)
 }
 
+// buildQuery builds query statement, error panicked by query builder such as unresolvable join is returned as error.
+func (s SQL) buildQuery(query rel.Query) (statement string, args []any, err error) {
+	defer recoverBuild(&err)
+
+	statement, args = s.QueryBuilder.Build(query)
+	return
+}
+
+func (s SQL) buildAggregate(query rel.Query, mode string, field string) (statement string, args []any, err error) {
+	defer recoverBuild(&err)
+
+	if builder, ok := s.QueryBuilder.(AggregateBuilder); ok {
+		statement, args = builder.BuildAggregate(query, mode, field)
+		return
+	}
+
+	aggregateField := "^" + mode + "(" + field + ") AS result"
+	statement, args = s.QueryBuilder.Build(query.Select(append([]string{aggregateField}, query.GroupQuery.Fields...)...))
+	return
+}
+
+// BuildError is panicked by builder when statement can't be built, such as operation that is not supported by the dialect.
+// It's recovered and returned as error by the adapter, any other panic is propagated.
+type BuildError struct {
+	Err error
+}
+
+// Error returns the message of the wrapped error.
+func (be BuildError) Error() string {
+	return be.Err.Error()
+}
+
+// Unwrap returns the wrapped error, so that it can be matched using errors.Is.
+func (be BuildError) Unwrap() error {
+	return be.Err
+}
+
+// recoverBuild recovers BuildError panicked by builder and returns the wrapped error.
+func recoverBuild(err *error) {
+	if p := recover(); p != nil {
+		be, ok := p.(BuildError)
+		if !ok {
+			panic(p)
+		}
+
+		*err = be.Err
+	}
+}
+
 // Exec performs exec operation.
 func (s SQL) Exec(ctx context.Context, statement string, args []any) (int64, int64, error) {
 	res, err := s.DoExec(ctx, statement, args)
@@ -192,13 +407,15 @@ func (s SQL) Exec(ctx context.Context, statement string, args []any) (int64, int
 // Aggregate record using given query.
 func (s SQL) Aggregate(ctx context.Context, query rel.Query, mode string, field string) (int, error) {
 	var (
-		out             sql.NullInt64
-		aggregateField  = "^" + mode + "(" + field + ") AS result"
-		aggregateQuery  = query.Select(append([]string{aggregateField}, query.GroupQuery.Fields...)...)
-		statement, args = s.QueryBuilder.Build(aggregateQuery)
-		rows, err       = s.DoQuery(ctx, statement, args)
+		out sql.NullInt64
 	)
 
+	statement, args, err := s.buildAggregate(query, mode, field)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := s.DoQueryRead(ctx, query, statement, args)
 	if err != nil {
 		return 0, s.ErrorMapper(err)
 	}
@@ -214,25 +431,46 @@ func (s SQL) Aggregate(ctx context.Context, query rel.Query, mode string, field
 
 // Insert inserts a record to database and returns its id.
 func (s SQL) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (any, error) {
-	var (
-		statement, args = s.InsertBuilder.Build(query.Table, primaryField, mutates, onConflict)
-		id, _, err      = s.Exec(ctx, statement, args)
-	)
+	statement, args, err := s.buildInsert(query, primaryField, mutates, onConflict)
+	if err != nil {
+		return nil, err
+	}
 
+	id, _, err := s.Exec(ctx, statement, args)
 	return id, err
 }
 
+func (s SQL) buildInsert(query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (statement string, args []any, err error) {
+	defer recoverBuild(&err)
+
+	statement, args = s.InsertBuilder.Build(query.Table, primaryField, mutates, onConflict)
+	return
+}
+
 // InsertAll inserts multiple records to database and returns its ids.
 func (s SQL) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) ([]any, error) {
-	var (
-		statement, args = s.InsertAllBuilder.Build(query.Table, primaryField, fields, bulkMutates, onConflict)
-		id, _, err      = s.Exec(ctx, statement, args)
-	)
+	statement, args, err := s.buildInsertAll(query, primaryField, fields, bulkMutates, onConflict)
+	if err != nil {
+		return nil, err
+	}
 
+	id, _, err := s.Exec(ctx, statement, args)
 	if err != nil {
 		return nil, err
 	}
 
+	return s.insertedIDs(id, primaryField, bulkMutates), nil
+}
+
+func (s SQL) buildInsertAll(query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) (statement string, args []any, err error) {
+	defer recoverBuild(&err)
+
+	statement, args = s.InsertAllBuilder.Build(query.Table, primaryField, fields, bulkMutates, onConflict)
+	return
+}
+
+// insertedIDs returns primary values of inserted records, generated values are calculated using increment.
+func (s SQL) insertedIDs(id int64, primaryField string, bulkMutates []map[string]rel.Mutate) []any {
 	var (
 		ids = make([]any, len(bulkMutates))
 		inc = s.Increment
@@ -261,32 +499,60 @@ func (s SQL) InsertAll(ctx context.Context, query rel.Query, primaryField string
 		}
 	}
 
-	return ids, nil
+	return ids
 }
 
 // Update updates a record in database.
 func (s SQL) Update(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate) (int, error) {
-	var (
-		statement, args      = s.UpdateBuilder.Build(query.Table, primaryField, mutates, query.WhereQuery)
-		_, updatedCount, err = s.Exec(ctx, statement, args)
-	)
+	statement, args, err := s.buildUpdate(query, primaryField, mutates)
+	if err != nil {
+		return 0, err
+	}
+
+	_, updatedCount, err := s.Exec(ctx, statement, args)
 
 	return int(updatedCount), err
 }
 
+func (s SQL) buildUpdate(query rel.Query, primaryField string, mutates map[string]rel.Mutate) (statement string, args []any, err error) {
+	defer recoverBuild(&err)
+
+	statement, args = s.UpdateBuilder.Build(query.Table, primaryField, mutates, query.WhereQuery)
+	return
+}
+
 // Delete deletes all results that match the query.
 func (s SQL) Delete(ctx context.Context, query rel.Query) (int, error) {
-	var (
-		statement, args      = s.DeleteBuilder.Build(query.Table, query.WhereQuery)
-		_, deletedCount, err = s.Exec(ctx, statement, args)
-	)
+	statement, args, err := s.buildDelete(query)
+	if err != nil {
+		return 0, err
+	}
+
+	_, deletedCount, err := s.Exec(ctx, statement, args)
 
 	return int(deletedCount), err
 }
 
+func (s SQL) buildDelete(query rel.Query) (statement string, args []any, err error) {
+	defer recoverBuild(&err)
+
+	statement, args = s.DeleteBuilder.Build(query.Table, query.WhereQuery)
+	return
+}
+
 // SchemaApply performs migration to database.
 func (s SQL) SchemaApply(ctx context.Context, migration rel.Migration) error {
-	var statement string
+	statement, err := s.buildMigration(migration)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.Exec(ctx, statement, nil)
+	return err
+}
+
+func (s SQL) buildMigration(migration rel.Migration) (statement string, err error) {
+	defer recoverBuild(&err)
 
 	switch v := migration.(type) {
 	case rel.Table:
@@ -297,8 +563,7 @@ func (s SQL) SchemaApply(ctx context.Context, migration rel.Migration) error {
 		statement = string(v)
 	}
 
-	_, _, err := s.Exec(ctx, statement, nil)
-	return err
+	return
 }
 
 // Apply performs migration to database.
@@ -0,0 +1,172 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTestBuild = errors.New("operation is not supported")
+
+type panicBuilder struct {
+	panic any
+}
+
+func (pb panicBuilder) Build(table string, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (string, []any) {
+	panic(pb.panic)
+}
+
+type aggregateBuilder struct{}
+
+func (aggregateBuilder) Build(query rel.Query) (string, []any) {
+	return "SELECT *", nil
+}
+
+func (aggregateBuilder) BuildAggregate(query rel.Query, mode string, field string) (string, []any) {
+	return "SELECT " + mode + "(" + field + ") FROM " + query.Table, nil
+}
+
+func TestSQL_Aggregate(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		d, db   = openTestDB(t)
+		adapter = &SQL{DB: db, QueryBuilder: aggregateBuilder{}, ErrorMapper: func(err error) error { return err }}
+	)
+
+	d.rows = [][]driver.Value{{int64(3)}}
+
+	count, err := adapter.Aggregate(ctx, rel.From("users"), "count", "*")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []string{"query SELECT count(*) FROM users"}, d.Log())
+}
+
+func TestRecoverBuild(t *testing.T) {
+	build := func(f func()) (err error) {
+		defer recoverBuild(&err)
+		f()
+		return nil
+	}
+
+	assert.Equal(t, errTestBuild, build(func() { panic(BuildError{Err: errTestBuild}) }))
+
+	assert.PanicsWithValue(t, errTestBuild, func() {
+		_ = build(func() { panic(errTestBuild) })
+	})
+
+	assert.Panics(t, func() {
+		var values []int
+		_ = build(func() { _ = values[1] })
+	})
+}
+
+func TestSQL_Insert_buildError(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		d, db   = openTestDB(t)
+		adapter = &SQL{DB: db, InsertBuilder: panicBuilder{panic: BuildError{Err: errTestBuild}}, ErrorMapper: func(err error) error { return err }}
+	)
+
+	_, err := adapter.Insert(ctx, rel.From("users"), "id", map[string]rel.Mutate{"name": rel.Set("name", "foo")}, rel.OnConflict{})
+	assert.Equal(t, errTestBuild, err)
+	assert.Empty(t, d.Log())
+}
+
+func TestSQL_Insert_panic(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		_, db   = openTestDB(t)
+		adapter = &SQL{DB: db, InsertBuilder: panicBuilder{panic: errors.New("unexpected")}, ErrorMapper: func(err error) error { return err }}
+	)
+
+	assert.Panics(t, func() {
+		_, _ = adapter.Insert(ctx, rel.From("users"), "id", nil, rel.OnConflict{})
+	})
+}
+
+func TestSQL_BeginTx(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		d, db   = openTestDB(t)
+		adapter = &SQL{DB: db, ErrorMapper: func(err error) error { return err }}
+		opts    = &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true}
+	)
+
+	tx, err := adapter.BeginTx(ctx, opts)
+	assert.Nil(t, err)
+	assert.Same(t, opts, tx.(*SQL).TxOptions)
+
+	// savepoint inherits options of the active transaction.
+	nested, err := tx.Begin(ctx)
+	assert.Nil(t, err)
+	assert.Same(t, opts, nested.(*SQL).TxOptions)
+	assert.Equal(t, 1, nested.(*SQL).Savepoint)
+
+	assert.Nil(t, nested.Commit(ctx))
+	assert.Nil(t, tx.Commit(ctx))
+	assert.Equal(t, []string{"begin Serializable READ ONLY", "exec SAVEPOINT s1;", "exec RELEASE SAVEPOINT s1;", "commit "}, d.Log())
+}
+
+func TestSQL_Begin_withTxOptions(t *testing.T) {
+	var (
+		d, db   = openTestDB(t)
+		adapter = &SQL{DB: db, ErrorMapper: func(err error) error { return err }}
+		ctx     = WithTxOptions(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	)
+
+	tx, err := adapter.Begin(ctx)
+	assert.Nil(t, err)
+
+	// the same options passed down by context doesn't conflict with the active transaction.
+	nested, err := tx.Begin(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, sql.LevelRepeatableRead, nested.(*SQL).TxOptions.Isolation)
+
+	assert.Nil(t, nested.Rollback(ctx))
+	assert.Nil(t, tx.Rollback(ctx))
+	assert.Equal(t, []string{"begin Repeatable Read", "exec SAVEPOINT s1;", "exec ROLLBACK TO SAVEPOINT s1;", "rollback "}, d.Log())
+}
+
+func TestSQL_BeginTx_conflict(t *testing.T) {
+	tests := []struct {
+		name   string
+		active *sql.TxOptions
+		opts   *sql.TxOptions
+	}{
+		{name: "isolation", active: &sql.TxOptions{Isolation: sql.LevelReadCommitted}, opts: &sql.TxOptions{Isolation: sql.LevelSerializable}},
+		{name: "default isolation", opts: &sql.TxOptions{Isolation: sql.LevelSerializable}},
+		{name: "read-only", active: &sql.TxOptions{Isolation: sql.LevelSerializable}, opts: &sql.TxOptions{ReadOnly: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx     = context.Background()
+				d, db   = openTestDB(t)
+				adapter = &SQL{DB: db, ErrorMapper: func(err error) error { return err }}
+			)
+
+			tx, err := adapter.BeginTx(ctx, test.active)
+			assert.Nil(t, err)
+
+			_, err = tx.(*SQL).BeginTx(ctx, test.opts)
+			assert.EqualError(t, err, "unable to change isolation level or read-only mode inside a savepoint")
+			assert.NotContains(t, d.Log(), "exec SAVEPOINT s1;")
+
+			assert.Nil(t, tx.Rollback(ctx))
+		})
+	}
+}
+
+func TestConflictTxOptions(t *testing.T) {
+	assert.False(t, conflictTxOptions(nil, nil))
+	assert.False(t, conflictTxOptions(&sql.TxOptions{ReadOnly: true}, &sql.TxOptions{}))
+	assert.False(t, conflictTxOptions(&sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true}, &sql.TxOptions{ReadOnly: true}))
+	assert.True(t, conflictTxOptions(nil, &sql.TxOptions{ReadOnly: true}))
+	assert.True(t, conflictTxOptions(&sql.TxOptions{Isolation: sql.LevelSerializable}, &sql.TxOptions{Isolation: sql.LevelReadCommitted}))
+}
@@ -0,0 +1,160 @@
+package sql
+
+import (
+	"container/list"
+	"database/sql"
+	"strings"
+	"sync"
+)
+
+// StmtCache is a bounded LRU cache of prepared statements keyed by database and statement text.
+//
+// Statement returned by the cache is referenced until it's released,
+// evicted statement is only closed after all of its references are released.
+type StmtCache struct {
+	size    int
+	mutex   sync.Mutex
+	entries map[stmtCacheKey]*list.Element
+	order   *list.List
+}
+
+type stmtCacheKey struct {
+	db        *sql.DB
+	statement string
+}
+
+type stmtCacheEntry struct {
+	key     stmtCacheKey
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+// Get returns cached statement and the function to release it, nil is returned if it's not cached.
+func (sc *StmtCache) Get(db *sql.DB, statement string) (*sql.Stmt, func()) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	if elem, ok := sc.entries[stmtCacheKey{db: db, statement: statement}]; ok {
+		sc.order.MoveToFront(elem)
+		return sc.acquire(elem.Value.(*stmtCacheEntry))
+	}
+
+	return nil, nil
+}
+
+// Put statement prepared on db to the cache and returns the cached statement and the function to release it.
+// Least recently used statements are evicted when the cache is full.
+func (sc *StmtCache) Put(db *sql.DB, statement string, stmt *sql.Stmt) (*sql.Stmt, func()) {
+	key := stmtCacheKey{db: db, statement: statement}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	// statement might be prepared concurrently, keep the cached one.
+	if elem, ok := sc.entries[key]; ok {
+		stmt.Close()
+		sc.order.MoveToFront(elem)
+		return sc.acquire(elem.Value.(*stmtCacheEntry))
+	}
+
+	entry := &stmtCacheEntry{key: key, stmt: stmt}
+	sc.entries[key] = sc.order.PushFront(entry)
+	stmt, release := sc.acquire(entry)
+
+	for sc.order.Len() > sc.size {
+		sc.evict(sc.order.Back())
+	}
+
+	return stmt, release
+}
+
+// acquire references entry until the returned function is called, mutex must be held by the caller.
+func (sc *StmtCache) acquire(entry *stmtCacheEntry) (*sql.Stmt, func()) {
+	var (
+		once sync.Once
+	)
+
+	entry.refs++
+
+	return entry.stmt, func() {
+		once.Do(func() {
+			sc.mutex.Lock()
+			defer sc.mutex.Unlock()
+
+			entry.refs--
+			if entry.evicted && entry.refs == 0 {
+				entry.stmt.Close()
+			}
+		})
+	}
+}
+
+// evict removes elem from the cache, the statement is closed when it's no longer referenced.
+func (sc *StmtCache) evict(elem *list.Element) error {
+	entry := sc.order.Remove(elem).(*stmtCacheEntry)
+	delete(sc.entries, entry.key)
+
+	entry.evicted = true
+	if entry.refs == 0 {
+		return entry.stmt.Close()
+	}
+
+	return nil
+}
+
+// Len returns the number of cached statements.
+func (sc *StmtCache) Len() int {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	return sc.order.Len()
+}
+
+// Close all cached statements, statements that are still in use are closed once released.
+func (sc *StmtCache) Close() error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	var err error
+	for sc.order.Len() > 0 {
+		if cerr := sc.evict(sc.order.Front()); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+// NewStmtCache creates prepared statement cache that holds at most size statements, size must be positive.
+func NewStmtCache(size int) *StmtCache {
+	if size < 1 {
+		panic("sql: statement cache size must be positive")
+	}
+
+	return &StmtCache{
+		size:    size,
+		entries: make(map[stmtCacheKey]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+// cacheableStatement returns true for a single DML statement,
+// DDL and multiple statements are not prepared since some databases can't prepare them.
+func cacheableStatement(statement string) bool {
+	statement = strings.TrimSpace(statement)
+	if i := strings.IndexByte(statement, ';'); i >= 0 && i != len(statement)-1 {
+		return false
+	}
+
+	if i := strings.IndexAny(statement, " \t\n("); i > 0 {
+		statement = statement[:i]
+	}
+
+	switch strings.ToUpper(statement) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "WITH":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,164 @@
+package sql
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStmtCache_eviction(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		d, db = openTestDB(t)
+		cache = NewStmtCache(2)
+	)
+
+	for _, statement := range []string{"SELECT 1", "SELECT 2", "SELECT 1", "SELECT 3"} {
+		stmt, release := cache.Get(db, statement)
+		if stmt == nil {
+			prepared, err := db.PrepareContext(ctx, statement)
+			assert.Nil(t, err)
+			stmt, release = cache.Put(db, statement, prepared)
+		}
+		release()
+	}
+
+	assert.Equal(t, 2, cache.Len())
+	assert.Contains(t, d.Log(), "close SELECT 2")
+	assert.NotContains(t, d.Log(), "close SELECT 1")
+
+	stmt, _ := cache.Get(db, "SELECT 2")
+	assert.Nil(t, stmt)
+}
+
+func TestStmtCache_evictionInUse(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		d, db = openTestDB(t)
+		cache = NewStmtCache(1)
+	)
+
+	prepared, _ := db.PrepareContext(ctx, "SELECT 1")
+	stmt, release := cache.Put(db, "SELECT 1", prepared)
+
+	prepared, _ = db.PrepareContext(ctx, "SELECT 2")
+	_, release2 := cache.Put(db, "SELECT 2", prepared)
+	release2()
+
+	// evicted, but still usable until released.
+	_, err := stmt.ExecContext(ctx)
+	assert.Nil(t, err)
+	assert.NotContains(t, d.Log(), "close SELECT 1")
+
+	release()
+	release()
+	assert.Contains(t, d.Log(), "close SELECT 1")
+}
+
+func TestStmtCache_size(t *testing.T) {
+	assert.Panics(t, func() { NewStmtCache(0) })
+	assert.Panics(t, func() { NewStmtCache(-1) })
+
+	var (
+		ctx   = context.Background()
+		_, db = openTestDB(t)
+		cache = NewStmtCache(1)
+	)
+
+	prepared, _ := db.PrepareContext(ctx, "SELECT 1")
+	stmt, release := cache.Put(db, "SELECT 1", prepared)
+	defer release()
+
+	_, err := stmt.ExecContext(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestStmtCache_concurrent(t *testing.T) {
+	var (
+		_, db   = openTestDB(t)
+		adapter = &SQL{DB: db, StmtCache: NewStmtCache(2), ErrorMapper: func(err error) error { return err }}
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				_, err := adapter.DoExec(context.Background(), "UPDATE t SET a="+strconv.Itoa((i+j)%5), nil)
+				assert.Nil(t, err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	assert.LessOrEqual(t, adapter.StmtCache.Len(), 2)
+	assert.Nil(t, adapter.StmtCache.Close())
+	assert.Equal(t, 0, adapter.StmtCache.Len())
+}
+
+func TestSQL_prepare(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		d, db   = openTestDB(t)
+		adapter = &SQL{DB: db, StmtCache: NewStmtCache(10), ErrorMapper: func(err error) error { return err }}
+	)
+
+	d.fails = []string{"prepare SELECT fail"}
+
+	_, err := adapter.DoExec(ctx, "CREATE TABLE t (a INT);", nil)
+	assert.Nil(t, err)
+	_, err = adapter.DoExec(ctx, "UPDATE t SET a=1; UPDATE t SET a=2;", nil)
+	assert.Nil(t, err)
+	rows, err := adapter.DoQuery(ctx, "SELECT fail", nil)
+	assert.Nil(t, err)
+	rows.Close()
+
+	assert.Equal(t, 0, adapter.StmtCache.Len())
+	assert.Equal(t, []string{
+		"exec CREATE TABLE t (a INT);",
+		"exec UPDATE t SET a=1; UPDATE t SET a=2;",
+		"prepare SELECT fail",
+		"query SELECT fail",
+	}, d.Log())
+}
+
+func TestSQL_prepareInTransaction(t *testing.T) {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+		d, db       = openTestDB(t)
+		adapter     = &SQL{DB: db, StmtCache: NewStmtCache(10), ErrorMapper: func(err error) error { return err }}
+	)
+
+	defer cancel()
+	db.SetMaxOpenConns(1)
+
+	_, err := adapter.DoExec(ctx, "UPDATE t SET a=1", nil)
+	assert.Nil(t, err)
+
+	tx, err := adapter.Begin(ctx)
+	assert.Nil(t, err)
+
+	// cached statement is bound to the transaction, the other isn't prepared while the transaction holds the only connection.
+	_, err = tx.(*SQL).DoExec(ctx, "UPDATE t SET a=1", nil)
+	assert.Nil(t, err)
+	_, err = tx.(*SQL).DoExec(ctx, "UPDATE t SET a=2", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Commit(ctx))
+
+	assert.Equal(t, 1, adapter.StmtCache.Len())
+	assert.Equal(t, []string{
+		"prepare UPDATE t SET a=1",
+		"exec UPDATE t SET a=1",
+		"begin ",
+		"exec UPDATE t SET a=1",
+		"exec UPDATE t SET a=2",
+		"commit ",
+	}, d.Log())
+}
@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,6 +11,19 @@ import (
 // DefaultTimeLayout default time layout.
 const DefaultTimeLayout = "2006-01-02 15:04:05"
 
+// ArraySuffix is the suffix of array column type.
+const ArraySuffix = "[]"
+
+// Array returns column type of array which element is typ, such as TEXT[].
+func Array(typ rel.ColumnType) rel.ColumnType {
+	return typ + ArraySuffix
+}
+
+// IsArray returns true if typ is array column type.
+func IsArray(typ rel.ColumnType) bool {
+	return strings.HasSuffix(string(typ), ArraySuffix)
+}
+
 func DropKeyMapper(keyType rel.KeyType) string {
 	return "CONSTRAINT"
 }
@@ -22,6 +36,11 @@ func ColumnMapper(column *rel.Column) (string, int, int) {
 		timeLayout = DefaultTimeLayout
 	)
 
+	if IsArray(column.Type) {
+		// no native array, stored as JSON array text the same way as JSON column.
+		return "TEXT", 0, 0
+	}
+
 	switch column.Type {
 	case rel.ID:
 		typ = "INT UNSIGNED AUTO_INCREMENT"
@@ -72,6 +91,53 @@ func ColumnMapper(column *rel.Column) (string, int, int) {
 	return typ, m, n
 }
 
+// ArrayColumnMapper returns column mapper that supports native array column, such as PostgreSQL's.
+// Element type of the array is mapped using mapper.
+func ArrayColumnMapper(mapper func(*rel.Column) (string, int, int)) func(*rel.Column) (string, int, int) {
+	return func(column *rel.Column) (string, int, int) {
+		if !IsArray(column.Type) {
+			return mapper(column)
+		}
+
+		var (
+			elem    = *column
+			typ     string
+			m, n    int
+			builder strings.Builder
+		)
+
+		elem.Type = column.Type[:len(column.Type)-len(ArraySuffix)]
+		typ, m, n = mapper(&elem)
+
+		builder.WriteString(typ)
+		if m != 0 {
+			builder.WriteByte('(')
+			builder.WriteString(strconv.Itoa(m))
+			if n != 0 {
+				builder.WriteByte(',')
+				builder.WriteString(strconv.Itoa(n))
+			}
+			builder.WriteByte(')')
+		}
+		builder.WriteString(ArraySuffix)
+
+		return builder.String(), 0, 0
+	}
+}
+
+// JSONColumnMapper returns column mapper that supports native JSON column, such as MySQL's JSON or PostgreSQL's JSONB.
+// JSON and array column are mapped to typ, since array without native support is stored as JSON array.
+// Other column is mapped using mapper.
+func JSONColumnMapper(mapper func(*rel.Column) (string, int, int), typ string) func(*rel.Column) (string, int, int) {
+	return func(column *rel.Column) (string, int, int) {
+		if column.Type == rel.JSON || IsArray(column.Type) {
+			return typ, 0, 0
+		}
+
+		return mapper(column)
+	}
+}
+
 // ColumnOptionsMapper function.
 func ColumnOptionsMapper(column *rel.Column) string {
 	var buffer strings.Builder
@@ -0,0 +1,36 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONColumnMapper(t *testing.T) {
+	mapper := JSONColumnMapper(ColumnMapper, "JSONB")
+
+	tests := []struct {
+		column rel.Column
+		typ    string
+		m      int
+	}{
+		{column: rel.Column{Type: rel.JSON}, typ: "JSONB"},
+		{column: rel.Column{Type: Array(rel.Int)}, typ: "JSONB"},
+		{column: rel.Column{Type: rel.String}, typ: "VARCHAR", m: 255},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.column.Type), func(t *testing.T) {
+			typ, m, _ := mapper(&test.column)
+			assert.Equal(t, test.typ, typ)
+			assert.Equal(t, test.m, m)
+		})
+	}
+
+	typ, _, _ := ColumnMapper(&rel.Column{Type: rel.JSON})
+	assert.Equal(t, "TEXT", typ)
+
+	typ, _, _ = ColumnMapper(&rel.Column{Type: Array(rel.Int)})
+	assert.Equal(t, "TEXT", typ)
+}